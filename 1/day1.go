@@ -0,0 +1,91 @@
+package day1
+
+import (
+	"slices"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+func init() {
+	runner.Register(1, solver{})
+}
+
+type solver struct{}
+
+func (solver) Part1(input string) (runner.Answer, error) {
+	list1, list2, err := parseLists(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(sumSortedPairs(list1, list2)), nil
+}
+
+func (solver) Part2(input string) (runner.Answer, error) {
+	list1, list2, err := parseLists(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(computeSimilarityScore(list1, list2)), nil
+}
+
+func parseLists(input string) ([]int, []int, error) {
+	lines := io.Lines(input)
+	list1 := make([]int, len(lines))
+	list2 := make([]int, len(lines))
+
+	for i, line := range lines {
+		values, err := line.Ints()
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(values) != 2 {
+			return nil, nil, line.Errorf(0, "expected 2 location IDs, got %d", len(values))
+		}
+		list1[i], list2[i] = values[0], values[1]
+	}
+
+	return list1, list2, nil
+}
+
+func sumSortedPairs(list1, list2 []int) int {
+	list1 = slices.Clone(list1)
+	list2 = slices.Clone(list2)
+
+	slices.Sort(list1)
+	slices.Sort(list2)
+
+	sum := 0
+	for i := range list1 {
+		v1 := list1[i]
+		v2 := list2[i]
+
+		if v2 > v1 {
+			sum += v2 - v1
+		} else {
+			sum += v1 - v2
+		}
+	}
+
+	return sum
+}
+
+func computeSimilarityScore(list1, list2 []int) int {
+	seen := make(map[int]int)
+
+	for _, val2 := range list2 {
+		if _, ok := seen[val2]; !ok {
+			seen[val2] = 0
+		}
+
+		seen[val2]++
+	}
+
+	sum := 0
+	for _, val := range list1 {
+		if count, ok := seen[val]; ok {
+			sum += val * count
+		}
+	}
+	return sum
+}
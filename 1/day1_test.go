@@ -0,0 +1,14 @@
+package day1
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "11", Part2: "31"},
+		{Input: "in.txt", Part1: "3569916", Part2: "26407426"},
+	})
+}
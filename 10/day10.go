@@ -0,0 +1,113 @@
+package day10
+
+import (
+	"fmt"
+
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/grid"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+type Map struct {
+	heights    *grid.Grid[int]
+	trailheads []geom.Vec2
+}
+
+func init() {
+	runner.Register(10, solver{})
+}
+
+type solver struct{}
+
+func (solver) Part1(input string) (runner.Answer, error) {
+	m, err := parseMap(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(computeTrailScores(m)), nil
+}
+
+func (solver) Part2(input string) (runner.Answer, error) {
+	m, err := parseMap(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(computeTrailRatings(m)), nil
+}
+
+func parseMap(input string) (Map, error) {
+	heights, err := grid.ParseFunc(input, func(_ geom.Vec2, char rune) (int, error) {
+		if char < '0' || char > '9' {
+			return 0, fmt.Errorf("expected digit, got %q", char)
+		}
+		return int(char - '0'), nil
+	})
+	if err != nil {
+		return Map{}, err
+	}
+	return Map{
+		heights:    heights,
+		trailheads: grid.FindAll(heights, 0),
+	}, nil
+}
+
+func computeTrailScores(m Map) int {
+	// Idea: given any position on the trail (not limited to just trailheads), we can compute the trail score for that position based on how many unique 9s are accessible. Then, the score for any trail leading into that position is that position's score + any other trails found.
+	sum := 0
+	// seenNines records which trailhead last reached each 9, so that it doesn't
+	// need to be cleared between trailheads
+	seenNines := grid.New[int](m.heights.Width, m.heights.Height)
+	for i, head := range m.trailheads {
+		sum += computeTrailScore(m, head, seenNines, i+1)
+
+	}
+	return sum
+}
+
+func computeTrailScore(m Map, p geom.Vec2, seenNines *grid.Grid[int], trailhead int) int {
+	currentHeight := m.heights.Get(p)
+	if currentHeight == 9 {
+		if seenNines.Get(p) != trailhead {
+			seenNines.Set(p, trailhead)
+			return 1
+		} else {
+			return 0
+		}
+
+	}
+
+	score := 0
+	for neighbor, height := range m.heights.Neighbors4(p) {
+		if height-currentHeight == 1 {
+			score += computeTrailScore(m, neighbor, seenNines, trailhead)
+		}
+	}
+
+	return score
+}
+
+func computeTrailRatings(m Map) int {
+	// similar to trail scores, but we care about all unique paths and not just unique endings.
+	sum := 0
+	for _, head := range m.trailheads {
+		sum += computeTrailRating(m, head)
+	}
+	return sum
+}
+
+func computeTrailRating(m Map, p geom.Vec2) int {
+	currentHeight := m.heights.Get(p)
+	if currentHeight == 9 {
+		return 1
+
+	}
+
+	score := 0
+	for neighbor, height := range m.heights.Neighbors4(p) {
+		if height-currentHeight == 1 {
+			score += computeTrailRating(m, neighbor)
+		}
+	}
+
+	return score
+}
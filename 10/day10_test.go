@@ -0,0 +1,15 @@
+package day10
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "1", Part2: "16"},
+		{Input: "test2.txt", Part1: "36", Part2: "81"},
+		{Input: "in.txt", Part1: "652", Part2: "1432"},
+	})
+}
@@ -1,44 +1,46 @@
-package main
+package day11
 
 import (
-	"fmt"
-	"os"
-	"strconv"
-	"strings"
-	"time"
-
 	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner"
 )
 
-func main() {
-	input := io.ReadInputFile(os.Args[1])
-	stones := parseStones(input)
-
-	stoneCount := blink(stones, 25)
-	fmt.Printf("stones: %d\n", stoneCount)
+func init() {
+	runner.Register(11, solver{})
+}
 
-	stones = parseStones(input)
+type solver struct{}
 
-	stoneCount2 := blink2(stones, 75)
-	fmt.Printf("stones: %d\n", stoneCount2)
+func (solver) Part1(input string) (runner.Answer, error) {
+	stones, err := parseStones(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(blink(stones, 25)), nil
 }
 
-func parseStones(input string) []int {
-	stoneStrs := strings.Fields(input)
+func (solver) Part2(input string) (runner.Answer, error) {
+	stones, err := parseStones(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(blink2(stones, 75)), nil
+}
 
-	stones := make([]int, len(stoneStrs))
-	for i, str := range stoneStrs {
-		value, _ := strconv.Atoi(str)
-		stones[i] = value
+func parseStones(input string) ([]int, error) {
+	stones := []int{}
+	for _, line := range io.Lines(input) {
+		values, err := line.Ints()
+		if err != nil {
+			return nil, err
+		}
+		stones = append(stones, values...)
 	}
-	return stones
+	return stones, nil
 }
 
 func blink(stones []int, n int) int {
-	funcBegin := time.Now()
-
 	for i := 0; i < n; i++ {
-		loopBegin := time.Now()
 		for j := 0; j < len(stones); j++ {
 			stone := stones[j]
 			magnitude := ord(stone)
@@ -56,11 +58,7 @@ func blink(stones []int, n int) int {
 				stones[j] = stone * 2024
 			}
 		}
-		loopTime := time.Since(loopBegin)
-		fmt.Printf("blink %d took %s\n", i+1, loopTime)
 	}
-	funcTime := time.Since(funcBegin)
-	fmt.Printf("took %s\n", funcTime)
 	return len(stones)
 }
 
@@ -71,7 +69,6 @@ func blink2(stones []int, n int) int {
 	// through "classes" of stones (eg. compute the result of blinking a 1, 2, 3,
 	// etc.) while keeping track of how many of each class exist. Then we add
 	// that many of the results of the blink to the next iteration.
-	funcBegin := time.Now()
 	stoneMap := make(map[int]int)
 	for _, stone := range stones {
 		if _, ok := stoneMap[stone]; !ok {
@@ -81,7 +78,6 @@ func blink2(stones []int, n int) int {
 	}
 
 	for i := 0; i < n; i++ {
-		loopBegin := time.Now()
 		nextStoneMap := make(map[int]int)
 		for stone, count := range stoneMap {
 			magnitude := ord(stone)
@@ -100,17 +96,12 @@ func blink2(stones []int, n int) int {
 		}
 
 		stoneMap = nextStoneMap
-
-		loopTime := time.Since(loopBegin)
-		fmt.Printf("blink %d took %s\n", i+1, loopTime)
 	}
 
 	sum := 0
 	for _, count := range stoneMap {
 		sum += count
 	}
-	funcTime := time.Since(funcBegin)
-	fmt.Printf("took %s\n", funcTime)
 	return sum
 }
 
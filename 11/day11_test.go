@@ -0,0 +1,15 @@
+package day11
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		// the puzzle only gives an answer for 25 blinks of the second example
+		{Input: "test2.txt", Part1: "55312"},
+		{Input: "in.txt", Part1: "218079", Part2: "259755538429618"},
+	})
+}
@@ -1,69 +1,59 @@
-package main
+package day12
 
 import (
-	"fmt"
-	"os"
-
-	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/grid"
+	"github.com/faideww/aoc-2024/lib/runner"
 )
 
-type Position struct {
-	x, y int
-}
-
 type Board struct {
-	width  int
-	height int
-	plants map[Position]rune
+	plants *grid.Grid[rune]
 }
 
 type Region struct {
 	plant     rune
 	area      int
 	perimeter int
-	cells     map[Position]bool
+	cells     []geom.Vec2
 }
 
-func main() {
-	input := io.ReadInputFile(os.Args[1])
+func init() {
+	runner.Register(12, solver{})
+}
 
-	board := parseBoard(input)
+type solver struct{}
 
+func (solver) Part1(input string) (runner.Answer, error) {
+	board, err := parseBoard(input)
+	if err != nil {
+		return nil, err
+	}
 	regions := findRegions(board)
+	return runner.Int(calcTotalCost(regions)), nil
+}
 
-	result := calcTotalCost(regions)
-	fmt.Printf("cost: %d\n", result)
-
-	sum := 0
-	for _, region := range regions {
-		corners := countSides(board, region)
-		sum += corners * region.area
+func (solver) Part2(input string) (runner.Answer, error) {
+	board, err := parseBoard(input)
+	if err != nil {
+		return nil, err
 	}
-
-	fmt.Printf("bulk cost:%d\n", sum)
+	regions := findRegions(board)
+	return runner.Int(calcBulkCost(board, regions)), nil
 }
 
-func parseBoard(input string) Board {
-	lines := io.TrimAndSplit(input)
-	height := len(lines)
-	width := len(lines[0])
-
-	plants := make(map[Position]rune)
-
-	for y, line := range lines {
-		for x, char := range line {
-			plants[Position{x, y}] = char
-		}
+func parseBoard(input string) (Board, error) {
+	plants, err := grid.Parse(input)
+	if err != nil {
+		return Board{}, err
 	}
-	return Board{width, height, plants}
+	return Board{plants}, nil
 }
 
 func findRegions(board Board) []Region {
-	newRegionFrontier := make([]Position, 0)
-	newRegionFrontier = append(newRegionFrontier, Position{0, 0})
+	newRegionFrontier := make([]geom.Vec2, 0)
+	newRegionFrontier = append(newRegionFrontier, geom.Vec2{X: 0, Y: 0})
 
-	// seenPlants := make(map[Position]bool)
-	seenRegions := make(map[Position]bool)
+	seenRegions := grid.New[bool](board.plants.Width, board.plants.Height)
 
 	regions := make([]Region, 0)
 
@@ -73,10 +63,10 @@ func findRegions(board Board) []Region {
 
 	for len(newRegionFrontier) > 0 {
 		regionStart := newRegionFrontier[0]
-		currentPlant := board.plants[regionStart]
+		currentPlant := board.plants.Get(regionStart)
 		newRegionFrontier = newRegionFrontier[1:]
 
-		if _, seen := seenRegions[regionStart]; seen {
+		if seenRegions.Get(regionStart) {
 			// if we've already encountered this plant as part of a region search, skip it
 			continue
 		}
@@ -84,29 +74,28 @@ func findRegions(board Board) []Region {
 		area := 0
 		perimeter := 0
 
-		sameRegionFrontier := make([]Position, 0)
+		sameRegionFrontier := make([]geom.Vec2, 0)
 		sameRegionFrontier = append(sameRegionFrontier, regionStart)
 
-		seenInRegion := make(map[Position]bool)
+		cells := make([]geom.Vec2, 0)
 
 		for len(sameRegionFrontier) > 0 {
 			currentPos := sameRegionFrontier[0]
 			sameRegionFrontier = sameRegionFrontier[1:]
-			if _, seen := seenInRegion[currentPos]; seen {
+			// regions are contiguous, so any seen plant of the same type
+			// reached from here must already be part of this region
+			if seenRegions.Get(currentPos) {
 				continue
 			}
-			seenInRegion[currentPos] = true
-			seenRegions[currentPos] = true
+			seenRegions.Set(currentPos, true)
+			cells = append(cells, currentPos)
 
 			// find neighboring plants
-			neighbors := findNeighbors(board, currentPos)
 			neighboringSamePlants := 0
 
-			for _, neighbor := range neighbors {
-				if board.plants[neighbor] == currentPlant {
+			for neighbor, plant := range board.plants.Neighbors4(currentPos) {
+				if plant == currentPlant {
 					neighboringSamePlants++
-				}
-				if board.plants[neighbor] == currentPlant {
 					sameRegionFrontier = append(sameRegionFrontier, neighbor)
 				} else {
 					newRegionFrontier = append(newRegionFrontier, neighbor)
@@ -121,7 +110,7 @@ func findRegions(board Board) []Region {
 			plant:     currentPlant,
 			area:      area,
 			perimeter: perimeter,
-			cells:     seenInRegion,
+			cells:     cells,
 		})
 
 	}
@@ -129,30 +118,21 @@ func findRegions(board Board) []Region {
 	return regions
 }
 
-func findNeighbors(board Board, pos Position) []Position {
-	neighbors := make([]Position, 0)
+func calcTotalCost(regions []Region) int {
+	sum := 0
 
-	if pos.x > 0 {
-		neighbors = append(neighbors, Position{pos.x - 1, pos.y})
-	}
-	if pos.x < board.width-1 {
-		neighbors = append(neighbors, Position{pos.x + 1, pos.y})
-	}
-	if pos.y > 0 {
-		neighbors = append(neighbors, Position{pos.x, pos.y - 1})
-	}
-	if pos.y < board.height-1 {
-		neighbors = append(neighbors, Position{pos.x, pos.y + 1})
+	for _, region := range regions {
+		sum += region.area * region.perimeter
 	}
 
-	return neighbors
+	return sum
 }
 
-func calcTotalCost(regions []Region) int {
+func calcBulkCost(board Board, regions []Region) int {
 	sum := 0
 
 	for _, region := range regions {
-		sum += region.area * region.perimeter
+		sum += countSides(board, region) * region.area
 	}
 
 	return sum
@@ -174,45 +154,50 @@ func countSides(board Board, region Region) int {
 
 	innerCorners := 0
 
-	for cell := range region.cells {
+	isOutside := func(x, y int) bool {
+		plant, ok := board.plants.Lookup(geom.Vec2{X: x, Y: y})
+		return !ok || plant != region.plant
+	}
+
+	for _, cell := range region.cells {
 		topLeftNeighbors := 0
 		topRightNeighbors := 0
 		bottomLeftNeighbors := 0
 		bottomRightNeighbors := 0
 		var tr, tl, br, bl, top, left, right, bottom bool
-		if plant, ok := board.plants[Position{cell.x - 1, cell.y - 1}]; !ok || plant != region.plant {
+		if isOutside(cell.X-1, cell.Y-1) {
 			tl = true
 			topLeftNeighbors++
 		}
-		if plant, ok := board.plants[Position{cell.x, cell.y - 1}]; !ok || plant != region.plant {
+		if isOutside(cell.X, cell.Y-1) {
 			top = true
 			topLeftNeighbors++
 			topRightNeighbors++
 		}
-		if plant, ok := board.plants[Position{cell.x + 1, cell.y - 1}]; !ok || plant != region.plant {
+		if isOutside(cell.X+1, cell.Y-1) {
 			tr = true
 			topRightNeighbors++
 		}
 
-		if plant, ok := board.plants[Position{cell.x + 1, cell.y}]; !ok || plant != region.plant {
+		if isOutside(cell.X+1, cell.Y) {
 			right = true
 			topRightNeighbors++
 			bottomRightNeighbors++
 		}
-		if plant, ok := board.plants[Position{cell.x + 1, cell.y + 1}]; !ok || plant != region.plant {
+		if isOutside(cell.X+1, cell.Y+1) {
 			br = true
 			bottomRightNeighbors++
 		}
-		if plant, ok := board.plants[Position{cell.x, cell.y + 1}]; !ok || plant != region.plant {
+		if isOutside(cell.X, cell.Y+1) {
 			bottom = true
 			bottomRightNeighbors++
 			bottomLeftNeighbors++
 		}
-		if plant, ok := board.plants[Position{cell.x - 1, cell.y + 1}]; !ok || plant != region.plant {
+		if isOutside(cell.X-1, cell.Y+1) {
 			bl = true
 			bottomLeftNeighbors++
 		}
-		if plant, ok := board.plants[Position{cell.x - 1, cell.y}]; !ok || plant != region.plant {
+		if isOutside(cell.X-1, cell.Y) {
 			left = true
 			bottomLeftNeighbors++
 			topLeftNeighbors++
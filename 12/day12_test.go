@@ -0,0 +1,18 @@
+package day12
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "140", Part2: "80"},
+		{Input: "test2.txt", Part1: "772", Part2: "436"},
+		{Input: "test3.txt", Part1: "1930", Part2: "1206"},
+		{Input: "test4.txt", Part1: "692", Part2: "236"},
+		{Input: "test5.txt", Part1: "1184", Part2: "368"},
+		{Input: "in.txt", Part1: "1396562", Part2: "844132"},
+	})
+}
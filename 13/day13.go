@@ -0,0 +1,545 @@
+package day13
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	stdio "io"
+	"maps"
+	"math"
+	"math/big"
+	"os"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+const PART2_PREFIX = 10000000000000
+
+// PART1_LIMIT is the most times part 1 lets any one button be pressed.
+const PART1_LIMIT = 100
+
+// MAX_COMBINATIONS caps how many press counts solveGame tries for the
+// buttons beyond the first two.
+const MAX_COMBINATIONS = 10_000_000
+
+var errTooManyCombinations = fmt.Errorf("more than %d combinations of presses to try; set a press limit with -limit or -limit2", MAX_COMBINATIONS)
+
+type Button struct {
+	name string
+	move geom.Vec2
+	cost int
+	// limit is the most times the button may be pressed, or 0 for no limit
+	limit int
+}
+
+type Game struct {
+	buttons []Button
+	prize   geom.Vec2
+	line    int
+}
+
+func init() {
+	runner.Register(13, newSolver())
+}
+
+func newSolver() *solver {
+	return &solver{
+		costs:  costFlag{"A": 3, "B": 1},
+		limit:  PART1_LIMIT,
+		offset: PART2_PREFIX,
+	}
+}
+
+type solver struct {
+	costs   costFlag
+	limit   int
+	limit2  int
+	offset  int
+	verbose bool
+}
+
+// Configure accepts flags for the button costs (-cost A=3,B=1), the press
+// limits in each part (-limit, -limit2), the prize offset in part 2 (-offset)
+// and -v, which reports every game that cannot be won and why on stderr.
+func (s *solver) Configure(args []string) error {
+	fs := flag.NewFlagSet("day 13", flag.ContinueOnError)
+	fs.Var(s.costs, "cost", "comma-separated `name=tokens` cost of each button")
+	fs.IntVar(&s.limit, "limit", s.limit, "most presses of each button in part 1, or 0 for no limit")
+	fs.IntVar(&s.limit2, "limit2", s.limit2, "most presses of each button in part 2, or 0 for no limit")
+	fs.IntVar(&s.offset, "offset", s.offset, "added to both prize coordinates in part 2")
+	fs.BoolVar(&s.verbose, "v", s.verbose, "report games that cannot be won")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if s.limit < 0 || s.limit2 < 0 {
+		return fmt.Errorf("press limits must not be negative")
+	}
+	return nil
+}
+
+func (s *solver) Part1(input string) (runner.Answer, error) {
+	games, err := parseGames(input, s.costs)
+	if err != nil {
+		return nil, err
+	}
+	setLimit(games, s.limit)
+	sum, err := sumMinTokenCounts(games, 0, s.report())
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(sum), nil
+}
+
+func (s *solver) Part2(input string) (runner.Answer, error) {
+	games, err := parseGames(input, s.costs)
+	if err != nil {
+		return nil, err
+	}
+	setLimit(games, s.limit2)
+	sum, err := sumMinTokenCounts(games, s.offset, s.report())
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(sum), nil
+}
+
+func setLimit(games []Game, limit int) {
+	for _, g := range games {
+		for i := range g.buttons {
+			g.buttons[i].limit = limit
+		}
+	}
+}
+
+func (s *solver) report() stdio.Writer {
+	if s.verbose {
+		return os.Stderr
+	}
+	return nil
+}
+
+// costFlag maps button names to their cost in tokens. Setting it adds to
+// (or overrides) the costs already there.
+type costFlag map[string]int
+
+func (c costFlag) String() string {
+	names := slices.Sorted(maps.Keys(c))
+	parts := make([]string, len(names))
+	for i, name := range names {
+		parts[i] = fmt.Sprintf("%s=%d", name, c[name])
+	}
+	return strings.Join(parts, ",")
+}
+
+func (c costFlag) Set(value string) error {
+	for _, part := range strings.Split(value, ",") {
+		name, tokens, ok := strings.Cut(part, "=")
+		if !ok || name == "" {
+			return fmt.Errorf("expected name=tokens, got %q", part)
+		}
+		cost, err := strconv.Atoi(tokens)
+		if err != nil {
+			return err
+		}
+		if cost < 0 {
+			return fmt.Errorf("button %s cannot cost %d tokens", name, cost)
+		}
+		c[name] = cost
+	}
+	return nil
+}
+
+// sumMinTokenCounts adds up the cheapest cost of every winnable game, with
+// each prize moved offset units further along both axes. Games that cannot
+// be won are skipped, and reported to w if it is not nil.
+func sumMinTokenCounts(games []Game, offset int, w stdio.Writer) (int, error) {
+	sum := 0
+	for i, g := range games {
+		g.prize.X += offset
+		g.prize.Y += offset
+		sol, err := solveGame(g)
+		if errors.Is(err, errTooManyCombinations) {
+			return 0, fmt.Errorf("machine %d (line %d): %w", i+1, g.line, err)
+		}
+		if err != nil {
+			if w != nil {
+				fmt.Fprintf(w, "machine %d (line %d): %v\n", i+1, g.line, err)
+			}
+			continue
+		}
+		sum += sol.cost
+	}
+	return sum, nil
+}
+
+// parseGames reads each machine as any number of button lines followed by
+// the prize, looking up every button's cost by name.
+func parseGames(input string, costs map[string]int) ([]Game, error) {
+	buttonRegex := regexp.MustCompile(`^Button (\w+): X\+(\d+), Y\+(\d+)$`)
+	prizeRegex := regexp.MustCompile(`^Prize: X=(\d+), Y=(\d+)$`)
+	games := []Game{}
+	for _, lines := range io.Sections(input) {
+		if len(lines) < 2 {
+			return nil, lines[0].Errorf(0, "expected at least one button and a prize, got %d lines", len(lines))
+		}
+
+		buttons := []Button{}
+		for _, line := range lines[:len(lines)-1] {
+			match, err := line.Match(buttonRegex)
+			if err != nil {
+				return nil, err
+			}
+			name := match[0].Text
+			if slices.ContainsFunc(buttons, func(b Button) bool { return b.name == name }) {
+				return nil, match[0].Errorf("button %s listed twice", name)
+			}
+			cost, ok := costs[name]
+			if !ok {
+				return nil, match[0].Errorf("no cost for button %s; set one with -cost %s=<tokens>", name, name)
+			}
+			move, err := parseVector(match[1:])
+			if err != nil {
+				return nil, err
+			}
+			buttons = append(buttons, Button{name: name, move: move, cost: cost})
+		}
+
+		match, err := lines[len(lines)-1].Match(prizeRegex)
+		if err != nil {
+			return nil, err
+		}
+		prize, err := parseVector(match)
+		if err != nil {
+			return nil, err
+		}
+
+		games = append(games, Game{
+			buttons: buttons,
+			prize:   prize,
+			line:    lines[0].Num,
+		})
+	}
+	return games, nil
+}
+
+func parseVector(fields []io.Field) (geom.Vec2, error) {
+	coords, err := io.Ints(fields)
+	if err != nil {
+		return geom.Vec2{}, err
+	}
+	return geom.Vec2{X: coords[0], Y: coords[1]}, nil
+}
+
+// Solution is how many times to press each button to win a game, and what
+// that costs.
+type Solution struct {
+	presses []int
+	cost    int
+}
+
+// solveGame finds the cheapest way to reach the prize exactly, within each
+// button's press limit. If the game cannot be won the error says why.
+//
+// Two buttons give two equations in two unknowns, which solvePair handles
+// exactly. Any further buttons are searched: every number of presses that
+// doesn't overshoot the prize is tried, leaving the rest of the distance to
+// the first two buttons.
+func solveGame(game Game) (Solution, error) {
+	buttons := game.buttons
+	switch len(buttons) {
+	case 0:
+		return Solution{}, fmt.Errorf("the machine has no buttons")
+	case 1:
+		n, err := solveSingle(buttons[0], game.prize)
+		if err != nil {
+			return Solution{}, err
+		}
+		return Solution{presses: []int{n}, cost: n * buttons[0].cost}, nil
+	}
+
+	presses := make([]int, len(buttons))
+	best := Solution{cost: -1}
+	var pairErr error
+	combinations := 0
+
+	var search func(i int, remaining geom.Vec2, cost int) error
+	search = func(i int, remaining geom.Vec2, cost int) error {
+		if i == len(buttons) {
+			if combinations++; combinations > MAX_COMBINATIONS {
+				return errTooManyCombinations
+			}
+			a, b, err := solvePair(buttons[0], buttons[1], remaining)
+			if err != nil {
+				pairErr = err
+				return nil
+			}
+			cost += a*buttons[0].cost + b*buttons[1].cost
+			if best.cost < 0 || cost < best.cost {
+				presses[0], presses[1] = a, b
+				best = Solution{presses: slices.Clone(presses), cost: cost}
+			}
+			return nil
+		}
+		for n := 0; n <= maxPresses(buttons[i], remaining); n++ {
+			presses[i] = n
+			if err := search(i+1, remaining.Sub(buttons[i].move.Scale(n)), cost+n*buttons[i].cost); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := search(2, game.prize, 0); err != nil {
+		return Solution{}, err
+	}
+
+	if best.cost < 0 {
+		if len(buttons) == 2 {
+			return Solution{}, pairErr
+		}
+		return Solution{}, fmt.Errorf("no combination of presses of %d buttons reaches the prize", len(buttons))
+	}
+	return best, nil
+}
+
+// maxPresses is the most times b can be pressed without passing the prize
+// along either axis, or exceeding its limit.
+func maxPresses(b Button, remaining geom.Vec2) int {
+	n := math.MaxInt
+	if b.limit > 0 {
+		n = b.limit
+	}
+	if b.move.X > 0 {
+		n = min(n, geom.FloorDiv(remaining.X, b.move.X))
+	}
+	if b.move.Y > 0 {
+		n = min(n, geom.FloorDiv(remaining.Y, b.move.Y))
+	}
+	if n == math.MaxInt {
+		// the button doesn't move the claw, so pressing it never helps
+		return 0
+	}
+	return n
+}
+
+// solveSingle finds how many presses of a lone button reach the prize.
+func solveSingle(b Button, prize geom.Vec2) (int, error) {
+	zero := geom.Vec2{}
+	if b.move == zero {
+		if prize == zero {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("button %s doesn't move the claw", b.name)
+	}
+	n := 0
+	if b.move.X != 0 {
+		n = prize.X / b.move.X
+	} else {
+		n = prize.Y / b.move.Y
+	}
+	if b.move.Scale(n) != prize {
+		return 0, fmt.Errorf("button %s moves along %v, which never lands on the prize", b.name, b.move)
+	}
+	if err := checkLimit(b, n); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// solvePair finds the cheapest presses (a, b) of two buttons that reach the
+// prize exactly, using only integer arithmetic. They satisfy
+//
+//	a*A.X + b*B.X = P.X
+//	a*A.Y + b*B.Y = P.Y
+//
+// which has a single solution when the buttons move in different directions,
+// found with Cramer's rule. When they are collinear there is either no
+// solution or a whole line of them, handled by solveCollinear.
+func solvePair(a, b Button, p geom.Vec2) (int, int, error) {
+	det, ok := cross(a.move, b.move)
+	if !ok {
+		return solvePairBig(a, b, p)
+	}
+	if det == 0 {
+		return solveCollinear(a, b, p)
+	}
+
+	aNum, ok1 := cross(p, b.move)
+	bNum, ok2 := cross(a.move, p)
+	if !ok1 || !ok2 {
+		return solvePairBig(a, b, p)
+	}
+	if det < 0 {
+		aNum, bNum, det = -aNum, -bNum, -det
+	}
+	if aNum%det != 0 || bNum%det != 0 {
+		return 0, 0, fmt.Errorf("the only way to reach the prize needs fractional presses (%s=%d/%d, %s=%d/%d)", a.name, aNum, det, b.name, bNum, det)
+	}
+	return checkPresses(a, b, aNum/det, bNum/det)
+}
+
+// solvePairBig is solvePair for coordinates large enough that the cross
+// products overflow an int.
+func solvePairBig(a, b Button, p geom.Vec2) (int, int, error) {
+	det := bigCross(a.move, b.move)
+	if det.Sign() == 0 {
+		return solveCollinear(a, b, p)
+	}
+	aNum, bNum := bigCross(p, b.move), bigCross(a.move, p)
+	if det.Sign() < 0 {
+		det.Neg(det)
+		aNum.Neg(aNum)
+		bNum.Neg(bNum)
+	}
+
+	var aPresses, bPresses, aRem, bRem big.Int
+	aPresses.QuoRem(aNum, det, &aRem)
+	bPresses.QuoRem(bNum, det, &bRem)
+	if aRem.Sign() != 0 || bRem.Sign() != 0 {
+		return 0, 0, fmt.Errorf("the only way to reach the prize needs fractional presses (%s=%s/%s, %s=%s/%s)", a.name, aNum, det, b.name, bNum, det)
+	}
+	if !aPresses.IsInt64() || !bPresses.IsInt64() {
+		return 0, 0, fmt.Errorf("the prize needs more presses than fit in an int (%s=%s, %s=%s)", a.name, &aPresses, b.name, &bPresses)
+	}
+	return checkPresses(a, b, int(aPresses.Int64()), int(bPresses.Int64()))
+}
+
+// checkPresses accepts the only solution for a pair of buttons if it is
+// non-negative and within their limits.
+func checkPresses(a, b Button, aPresses, bPresses int) (int, int, error) {
+	if aPresses < 0 || bPresses < 0 {
+		return 0, 0, fmt.Errorf("the only way to reach the prize needs negative presses (%s=%d, %s=%d)", a.name, aPresses, b.name, bPresses)
+	}
+	if err := checkLimit(a, aPresses); err != nil {
+		return 0, 0, err
+	}
+	if err := checkLimit(b, bPresses); err != nil {
+		return 0, 0, err
+	}
+	return aPresses, bPresses, nil
+}
+
+func checkLimit(b Button, presses int) error {
+	if b.limit > 0 && presses > b.limit {
+		return fmt.Errorf("reaching the prize needs %d presses of %s, more than the limit of %d", presses, b.name, b.limit)
+	}
+	return nil
+}
+
+// solveCollinear handles buttons that move along the same line. The prize
+// must lie on that line too, and then the two equations say the same thing,
+// leaving a*ax + b*bx = px for whichever axis the buttons move along. Its
+// integer solutions are
+//
+//	a = a0 + t*bx/g,  b = b0 - t*ax/g
+//
+// where g = gcd(ax, bx) and (a0, b0) come from the extended Euclidean
+// algorithm. The cost is linear in t, so the cheapest solution sits at one
+// end of the range of t that keeps both press counts non-negative and within
+// their limits.
+func solveCollinear(a, b Button, p geom.Vec2) (int, int, error) {
+	zero := geom.Vec2{}
+	if a.move == zero && b.move == zero {
+		if p == zero {
+			return 0, 0, nil
+		}
+		return 0, 0, fmt.Errorf("neither button moves the claw")
+	}
+	// the line the buttons move along
+	dir := a.move
+	if dir == zero {
+		dir = b.move
+	}
+	if c, ok := cross(dir, p); !ok || c != 0 {
+		return 0, 0, fmt.Errorf("the buttons both move along %v, which never passes through the prize", dir)
+	}
+
+	ax, bx, px := a.move.X, b.move.X, p.X
+	if dir.X == 0 {
+		ax, bx, px = a.move.Y, b.move.Y, p.Y
+	}
+
+	g, x0, y0 := geom.ExtendedGCD(ax, bx)
+	if px%g != 0 {
+		return 0, 0, fmt.Errorf("the buttons only move in steps of %d along %v, which never lands on the prize", g, dir)
+	}
+	scale := px / g
+	a0, b0 := x0*scale, y0*scale
+	da, db := bx/g, -ax/g // change in presses per unit of t
+
+	// 0 <= a0 + t*da <= limit, and likewise for b, bound t from both sides,
+	// unless a button doesn't move along this axis at all
+	lo, hi := math.MinInt, math.MaxInt
+	for _, bound := range []struct {
+		b    Button
+		v, d int
+	}{{a, a0, da}, {b, b0, db}} {
+		v, d := bound.v, bound.d
+		switch {
+		case d > 0:
+			lo = max(lo, geom.CeilDiv(-v, d))
+			if bound.b.limit > 0 {
+				hi = min(hi, geom.FloorDiv(bound.b.limit-v, d))
+			}
+		case d < 0:
+			hi = min(hi, geom.FloorDiv(v, -d))
+			if bound.b.limit > 0 {
+				lo = max(lo, geom.CeilDiv(v-bound.b.limit, -d))
+			}
+		case v < 0:
+			return 0, 0, fmt.Errorf("the prize cannot be reached without negative presses")
+		default:
+			if err := checkLimit(bound.b, v); err != nil {
+				return 0, 0, err
+			}
+		}
+	}
+	if lo > hi {
+		return 0, 0, fmt.Errorf("every way to reach the prize needs negative presses or more than the press limit")
+	}
+
+	costPerT := da*a.cost + db*b.cost
+	t := lo
+	if costPerT < 0 || (costPerT == 0 && lo == math.MinInt) {
+		t = hi
+	}
+	if t == math.MinInt || t == math.MaxInt {
+		return 0, 0, fmt.Errorf("the cost of reaching the prize has no lower bound")
+	}
+	return a0 + t*da, b0 + t*db, nil
+}
+
+// cross returns the 2D cross product u.X*v.Y - u.Y*v.X, and false if it
+// overflowed.
+func cross(u, v geom.Vec2) (int, bool) {
+	l, ok1 := mul(u.X, v.Y)
+	r, ok2 := mul(u.Y, v.X)
+	d := l - r
+	if !ok1 || !ok2 || (r > 0 && d > l) || (r < 0 && d < l) {
+		return 0, false
+	}
+	return d, true
+}
+
+func bigCross(u, v geom.Vec2) *big.Int {
+	l := new(big.Int).Mul(big.NewInt(int64(u.X)), big.NewInt(int64(v.Y)))
+	r := new(big.Int).Mul(big.NewInt(int64(u.Y)), big.NewInt(int64(v.X)))
+	return l.Sub(l, r)
+}
+
+// mul multiplies a and b, reporting false if the result overflowed.
+func mul(a, b int) (int, bool) {
+	if a == 0 || b == 0 {
+		return 0, true
+	}
+	c := a * b
+	if c/b != a || (a == -1 && b == math.MinInt) || (b == -1 && a == math.MinInt) {
+		return 0, false
+	}
+	return c, true
+}
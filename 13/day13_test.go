@@ -0,0 +1,143 @@
+package day13
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, newSolver, []runnertest.Case{
+		{Input: "test.txt", Part1: "480", Part2: "875318608908"},
+		{Input: "in.txt", Part1: "29436", Part2: "103729094227877"},
+	})
+}
+
+func TestSolveGame(t *testing.T) {
+	button := func(name string, x, y, cost, limit int) Button {
+		return Button{name: name, move: geom.Vec2{X: x, Y: y}, cost: cost, limit: limit}
+	}
+	tests := []struct {
+		name    string
+		buttons []Button
+		prize   geom.Vec2
+		// presses is nil when the game cannot be won
+		presses []int
+		cost    int
+	}{
+		{
+			name:    "sample machine",
+			buttons: []Button{button("A", 94, 34, 3, 100), button("B", 22, 67, 1, 100)},
+			prize:   geom.Vec2{X: 8400, Y: 5400},
+			presses: []int{80, 40},
+			cost:    280,
+		},
+		{
+			name:    "limit removes the only solution",
+			buttons: []Button{button("A", 94, 34, 3, 50), button("B", 22, 67, 1, 50)},
+			prize:   geom.Vec2{X: 8400, Y: 5400},
+		},
+		{
+			name:    "limit just allows the only solution",
+			buttons: []Button{button("A", 94, 34, 3, 80), button("B", 22, 67, 1, 40)},
+			prize:   geom.Vec2{X: 8400, Y: 5400},
+			presses: []int{80, 40},
+			cost:    280,
+		},
+		{
+			name:    "fractional presses",
+			buttons: []Button{button("A", 26, 66, 3, 0), button("B", 67, 21, 1, 0)},
+			prize:   geom.Vec2{X: 12748, Y: 12176},
+		},
+		{
+			name:    "negative presses",
+			buttons: []Button{button("A", 1, 0, 3, 0), button("B", 0, 1, 1, 0)},
+			prize:   geom.Vec2{X: -2, Y: 5},
+		},
+		{
+			name:    "collinear, cheaper big steps",
+			buttons: []Button{button("A", 1, 1, 3, 0), button("B", 2, 2, 1, 0)},
+			prize:   geom.Vec2{X: 11, Y: 11},
+			presses: []int{1, 5},
+			cost:    8,
+		},
+		{
+			name:    "collinear, cheaper small steps",
+			buttons: []Button{button("A", 1, 1, 1, 0), button("B", 3, 3, 10, 0)},
+			prize:   geom.Vec2{X: 9, Y: 9},
+			presses: []int{9, 0},
+			cost:    9,
+		},
+		{
+			name:    "collinear with a limit",
+			buttons: []Button{button("A", 1, 1, 1, 3), button("B", 3, 3, 10, 0)},
+			prize:   geom.Vec2{X: 9, Y: 9},
+			presses: []int{3, 2},
+			cost:    23,
+		},
+		{
+			name:    "collinear off the line",
+			buttons: []Button{button("A", 1, 1, 3, 0), button("B", 2, 2, 1, 0)},
+			prize:   geom.Vec2{X: 10, Y: 11},
+		},
+		{
+			name:    "collinear in too large steps",
+			buttons: []Button{button("A", 2, 2, 3, 0), button("B", 4, 4, 1, 0)},
+			prize:   geom.Vec2{X: 5, Y: 5},
+		},
+		{
+			// the cross products come to around 1e19, past the largest int
+			name:    "overflowing prize",
+			buttons: []Button{button("A", 1_000_000, 1, 3, 0), button("B", 1, 1_000_000, 1, 0)},
+			prize:   geom.Vec2{X: 10_000_020_000_000, Y: 20_000_010_000_000},
+			presses: []int{10_000_000, 20_000_000},
+			cost:    50_000_000,
+		},
+		{
+			name:    "overflowing prize, fractional presses",
+			buttons: []Button{button("A", 1_000_000, 1, 3, 0), button("B", 1, 1_000_000, 1, 0)},
+			prize:   geom.Vec2{X: 10_000_020_000_001, Y: 20_000_010_000_000},
+		},
+		{
+			name:    "three buttons, cheap diagonal",
+			buttons: []Button{button("A", 1, 0, 3, 0), button("B", 0, 1, 1, 0), button("C", 1, 1, 1, 0)},
+			prize:   geom.Vec2{X: 5, Y: 7},
+			presses: []int{0, 2, 5},
+			cost:    7,
+		},
+		{
+			name:    "three buttons, costly diagonal",
+			buttons: []Button{button("A", 1, 0, 3, 0), button("B", 0, 1, 1, 0), button("C", 1, 1, 5, 0)},
+			prize:   geom.Vec2{X: 5, Y: 7},
+			presses: []int{5, 7, 0},
+			cost:    22,
+		},
+		{
+			name:    "three buttons, limited diagonal",
+			buttons: []Button{button("A", 1, 0, 3, 0), button("B", 0, 1, 1, 0), button("C", 1, 1, 1, 3)},
+			prize:   geom.Vec2{X: 5, Y: 7},
+			presses: []int{2, 4, 3},
+			cost:    13,
+		},
+		{
+			name:    "single button",
+			buttons: []Button{button("A", 2, 3, 3, 0)},
+			prize:   geom.Vec2{X: 8, Y: 12},
+			presses: []int{4},
+			cost:    12,
+		},
+	}
+	for _, tt := range tests {
+		sol, err := solveGame(Game{buttons: tt.buttons, prize: tt.prize})
+		switch {
+		case tt.presses == nil && err == nil:
+			t.Errorf("%s: got presses %v costing %d, want no solution", tt.name, sol.presses, sol.cost)
+		case tt.presses != nil && err != nil:
+			t.Errorf("%s: %v", tt.name, err)
+		case tt.presses != nil && (!slices.Equal(sol.presses, tt.presses) || sol.cost != tt.cost):
+			t.Errorf("%s: got presses %v costing %d, want %v costing %d", tt.name, sol.presses, sol.cost, tt.presses, tt.cost)
+		}
+	}
+}
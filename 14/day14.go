@@ -0,0 +1,350 @@
+package day14
+
+import (
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+// PART1_STEPS is how long part 1 lets the robots move by default.
+const PART1_STEPS = 100
+
+// ARENAS are the sizes the puzzle uses: the example's and the real one.
+var ARENAS = []geom.Vec2{{X: 11, Y: 7}, {X: 101, Y: 103}}
+
+type Robot struct {
+	position geom.Vec2
+	velocity geom.Vec2
+}
+
+type Board struct {
+	width, height int
+	robots        []Robot
+}
+
+func init() {
+	runner.Register(14, newSolver())
+}
+
+func newSolver() *solver {
+	return &solver{steps: PART1_STEPS, split: Split{2, 2}, method: "crt", top: 10, rank: "cluster"}
+}
+
+type solver struct {
+	size   geom.Vec2
+	steps  int
+	split  Split
+	method string
+	top    int
+	rank   string
+}
+
+// Configure sets the arena size (-width and -height, inferred from the
+// robots when left at 0), how many steps part 1 runs for (-steps) and how it
+// divides the arena into regions (-split, columns x rows).
+//
+// It also picks how part 2 finds the picture: -method crt (the default)
+// jumps straight to it, -method unique looks for the first step where no two
+// robots overlap, and -method scan scores every step, printing the best -top
+// of them by -rank (variance, entropy or cluster) to stderr.
+func (s *solver) Configure(args []string) error {
+	fs := flag.NewFlagSet("day 14", flag.ContinueOnError)
+	fs.IntVar(&s.size.X, "width", s.size.X, "arena width, or 0 to infer it from the robots")
+	fs.IntVar(&s.size.Y, "height", s.size.Y, "arena height, or 0 to infer it from the robots")
+	fs.IntVar(&s.steps, "steps", s.steps, "how many steps part 1 runs for")
+	fs.Var(&s.split, "split", "`columns`x`rows` of regions the safety factor multiplies together")
+	fs.StringVar(&s.method, "method", s.method, "how to find the picture: crt, unique or scan")
+	fs.IntVar(&s.top, "top", s.top, "how many candidate steps -method scan reports")
+	fs.StringVar(&s.rank, "rank", s.rank, "what -method scan ranks steps by: variance, entropy or cluster")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	switch s.method {
+	case "crt", "unique", "scan":
+	default:
+		return fmt.Errorf("unknown method %q, expected crt, unique or scan", s.method)
+	}
+	if _, ok := rankings[s.rank]; !ok {
+		return fmt.Errorf("unknown ranking %q, expected variance, entropy or cluster", s.rank)
+	}
+	if s.top < 1 {
+		return fmt.Errorf("-top must be at least 1, got %d", s.top)
+	}
+	if s.size.X < 0 || s.size.Y < 0 {
+		return fmt.Errorf("arena size must not be negative, got %dx%d", s.size.X, s.size.Y)
+	}
+	return nil
+}
+
+func (s *solver) Part1(input string) (runner.Answer, error) {
+	board, err := ParseBoard(input, s.size)
+	if err != nil {
+		return nil, err
+	}
+	advanceBoard(&board, s.steps)
+	factor, err := computeSafetyFactor(board, s.split)
+	if err != nil {
+		return nil, err
+	}
+	return factor, nil
+}
+
+func (s *solver) Part2(input string) (runner.Answer, error) {
+	board, err := ParseBoard(input, s.size)
+	if err != nil {
+		return nil, err
+	}
+	switch s.method {
+	case "unique":
+		step, ok := findUniqueStep(board)
+		if !ok {
+			return nil, fmt.Errorf("robots never occupy all-unique positions")
+		}
+		return runner.Int(step), nil
+	case "scan":
+		top := scanSteps(board, s.top, rankings[s.rank])
+		for _, score := range top {
+			fmt.Fprintln(os.Stderr, score)
+		}
+		return runner.Int(top[0].Step), nil
+	}
+	step, err := findPictureCRT(board)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(step), nil
+}
+
+// ParseBoard reads one robot per line. If either side of size is 0 it is
+// inferred from the robots' starting positions: taken from the smallest of
+// ARENAS that holds every robot, or failing that one more than the largest
+// coordinate along that axis.
+func ParseBoard(input string, size geom.Vec2) (Board, error) {
+	botRegex := regexp.MustCompile(`^p=(.+),(.+) v=(.+),(.+)$`)
+
+	robots := make([]Robot, 0)
+	lines := io.Lines(input)
+
+	for _, line := range lines {
+		match, err := line.Match(botRegex)
+		if err != nil {
+			return Board{}, err
+		}
+		values, err := io.Ints(match)
+		if err != nil {
+			return Board{}, err
+		}
+		r := Robot{
+			geom.Vec2{X: values[0], Y: values[1]},
+			geom.Vec2{X: values[2], Y: values[3]},
+		}
+
+		robots = append(robots, r)
+
+	}
+
+	inferred := size
+	if size.X == 0 || size.Y == 0 {
+		inferred = inferSize(robots, size)
+	}
+	for i, r := range robots {
+		p := r.position
+		if p.X < 0 || p.Y < 0 || p.X >= inferred.X || p.Y >= inferred.Y {
+			return Board{}, lines[i].Errorf(0, "robot starts at %v, outside the %dx%d arena", p, inferred.X, inferred.Y)
+		}
+	}
+
+	return Board{
+		width:  inferred.X,
+		height: inferred.Y,
+		robots: robots,
+	}, nil
+}
+
+func inferSize(robots []Robot, size geom.Vec2) geom.Vec2 {
+	extent := geom.Vec2{}
+	for _, r := range robots {
+		extent.X = max(extent.X, r.position.X+1)
+		extent.Y = max(extent.Y, r.position.Y+1)
+	}
+	inferred := extent
+	for _, a := range ARENAS {
+		if a.X >= extent.X && a.Y >= extent.Y {
+			inferred = a
+			break
+		}
+	}
+	if size.X != 0 {
+		inferred.X = size.X
+	}
+	if size.Y != 0 {
+		inferred.Y = size.Y
+	}
+	return inferred
+}
+
+func advanceBoard(board *Board, steps int) {
+	size := geom.Vec2{X: board.width, Y: board.height}
+	for rIdx, r := range board.robots {
+		board.robots[rIdx].position = r.position.Add(r.velocity.Scale(steps)).Wrap(size)
+	}
+}
+
+// computeSafetyFactor multiplies together the number of robots in each
+// region of the split. Robots on the lines between regions don't count. With
+// more than a handful of regions the product outgrows an int.
+func computeSafetyFactor(board Board, split Split) (*big.Int, error) {
+	cols, err := splitAxis(board.width, split.Cols)
+	if err != nil {
+		return nil, fmt.Errorf("splitting the width: %w", err)
+	}
+	rows, err := splitAxis(board.height, split.Rows)
+	if err != nil {
+		return nil, fmt.Errorf("splitting the height: %w", err)
+	}
+
+	counts := make([]int, split.Cols*split.Rows)
+	for _, r := range board.robots {
+		col, row := cols.region(r.position.X), rows.region(r.position.Y)
+		if col >= 0 && row >= 0 {
+			counts[row*split.Cols+col]++
+		}
+	}
+	factor := big.NewInt(1)
+	for _, c := range counts {
+		factor.Mul(factor, big.NewInt(int64(c)))
+	}
+	return factor, nil
+}
+
+// Split is how many columns and rows of regions the arena is divided into.
+type Split struct {
+	Cols, Rows int
+}
+
+func (s *Split) String() string {
+	return fmt.Sprintf("%dx%d", s.Cols, s.Rows)
+}
+
+func (s *Split) Set(value string) error {
+	cols, rows, ok := strings.Cut(value, "x")
+	if !ok {
+		return fmt.Errorf("expected <columns>x<rows>, got %q", value)
+	}
+	c, err := strconv.Atoi(cols)
+	if err != nil {
+		return err
+	}
+	r, err := strconv.Atoi(rows)
+	if err != nil {
+		return err
+	}
+	if c < 1 || r < 1 {
+		return fmt.Errorf("need at least one column and row, got %q", value)
+	}
+	s.Cols, s.Rows = c, r
+	return nil
+}
+
+// axisSplit records which region each tile along one axis belongs to, or -1
+// for the tiles dividing them.
+type axisSplit struct {
+	regionOf []int
+}
+
+// splitAxis divides length tiles into parts regions separated by dividers
+// one tile wide, like the puzzle's 101 wide arena, which splits into 50, a
+// divider and 50. When the regions can't all be the same size, the earlier
+// ones get a tile more than the later ones.
+func splitAxis(length, parts int) (axisSplit, error) {
+	if parts < 1 {
+		return axisSplit{}, fmt.Errorf("cannot split into %d parts", parts)
+	}
+	usable := length - (parts - 1)
+	if usable < parts {
+		return axisSplit{}, fmt.Errorf("%d tiles are too few to split into %d parts", length, parts)
+	}
+	size, extra := usable/parts, usable%parts
+
+	regionOf := make([]int, 0, length)
+	for r := 0; r < parts; r++ {
+		if r > 0 {
+			regionOf = append(regionOf, -1)
+		}
+		n := size
+		if r < extra {
+			n++
+		}
+		for i := 0; i < n; i++ {
+			regionOf = append(regionOf, r)
+		}
+	}
+	return axisSplit{regionOf}, nil
+}
+
+// region returns which region v falls in, or -1 if it is on a divider.
+func (a axisSplit) region(v int) int {
+	return a.regionOf[v]
+}
+
+// findUniqueStep looks for the first step where no two robots share a tile,
+// which happens to be the picture for some inputs. It gives up once the
+// robots are back where they started.
+func findUniqueStep(board Board) (int, bool) {
+	period := board.width / geom.GCD(board.width, board.height) * board.height
+	for i := 1; i <= period; i++ {
+		advanceBoard(&board, 1)
+		if isBoardUnique(board) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+func isBoardUnique(board Board) bool {
+	robots := make(map[int]map[int]int)
+	for _, r := range board.robots {
+		if _, ok := robots[r.position.Y]; !ok {
+			robots[r.position.Y] = make(map[int]int)
+		}
+
+		if robots[r.position.Y][r.position.X] > 0 {
+			return false
+		}
+
+		robots[r.position.Y][r.position.X]++
+	}
+
+	return true
+}
+
+func printBoard(board Board) {
+	robots := make(map[int]map[int]int)
+
+	for _, r := range board.robots {
+		if _, ok := robots[r.position.Y]; !ok {
+			robots[r.position.Y] = make(map[int]int)
+		}
+
+		robots[r.position.Y][r.position.X]++
+	}
+
+	for y := 0; y < board.height; y++ {
+		for x := 0; x < board.width; x++ {
+			if count, ok := robots[y][x]; ok && count > 0 {
+				fmt.Printf("%d", count)
+			} else {
+				fmt.Printf(".")
+			}
+		}
+		fmt.Printf("\n")
+	}
+}
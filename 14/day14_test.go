@@ -0,0 +1,80 @@
+package day14
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, newSolver, []runnertest.Case{
+		{Input: "test.txt", Part1: "12"},
+		{Input: "in.txt", Part1: "216027840", Part2: "6876"},
+		{Input: "in.txt", Args: []string{"-split", "3x3"}, Part1: "3289098234240000"},
+	})
+}
+
+func TestSplitAxis(t *testing.T) {
+	tests := []struct {
+		length, parts int
+		// sizes of the regions, in order
+		want []int
+	}{
+		{length: 101, parts: 2, want: []int{50, 50}},
+		{length: 103, parts: 2, want: []int{51, 51}},
+		{length: 101, parts: 3, want: []int{33, 33, 33}},
+		{length: 103, parts: 3, want: []int{34, 34, 33}},
+		{length: 101, parts: 4, want: []int{25, 25, 24, 24}},
+		{length: 10, parts: 2, want: []int{5, 4}},
+		{length: 7, parts: 1, want: []int{7}},
+	}
+	for _, tt := range tests {
+		split, err := splitAxis(tt.length, tt.parts)
+		if err != nil {
+			t.Errorf("splitAxis(%d, %d): %v", tt.length, tt.parts, err)
+			continue
+		}
+		sizes := make([]int, tt.parts)
+		dividers := 0
+		for v := range tt.length {
+			if r := split.region(v); r < 0 {
+				dividers++
+			} else {
+				sizes[r]++
+			}
+		}
+		if !slices.Equal(sizes, tt.want) || dividers != tt.parts-1 {
+			t.Errorf("splitAxis(%d, %d) = regions %v and %d dividers, want %v and %d", tt.length, tt.parts, sizes, dividers, tt.want, tt.parts-1)
+		}
+	}
+
+	if _, err := splitAxis(7, 5); err == nil {
+		t.Errorf("splitAxis(7, 5) succeeded, want an error")
+	}
+}
+
+func TestInferSize(t *testing.T) {
+	tests := []struct {
+		name   string
+		robots []geom.Vec2
+		size   geom.Vec2
+		want   geom.Vec2
+	}{
+		{"example", []geom.Vec2{{X: 10, Y: 6}}, geom.Vec2{}, geom.Vec2{X: 11, Y: 7}},
+		{"tall", []geom.Vec2{{X: 3, Y: 50}}, geom.Vec2{}, geom.Vec2{X: 101, Y: 103}},
+		{"wide", []geom.Vec2{{X: 50, Y: 3}}, geom.Vec2{}, geom.Vec2{X: 101, Y: 103}},
+		{"too big", []geom.Vec2{{X: 200, Y: 3}}, geom.Vec2{}, geom.Vec2{X: 201, Y: 4}},
+		{"width given", []geom.Vec2{{X: 3, Y: 3}}, geom.Vec2{X: 20}, geom.Vec2{X: 20, Y: 7}},
+	}
+	for _, tt := range tests {
+		robots := []Robot{}
+		for _, p := range tt.robots {
+			robots = append(robots, Robot{position: p})
+		}
+		if got := inferSize(robots, tt.size); got != tt.want {
+			t.Errorf("%s: inferSize = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
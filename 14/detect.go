@@ -0,0 +1,182 @@
+package day14
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/faideww/aoc-2024/lib/geom"
+)
+
+// ENTROPY_BLOCK is the side of the square cells robots are counted in when
+// measuring the entropy of the board.
+const ENTROPY_BLOCK = 5
+
+// Scores measures how much structure the robots show at one step. A picture
+// packs most robots into a small area, so it has low variance and entropy
+// and a large connected cluster.
+type Scores struct {
+	Step       int
+	VarX, VarY float64
+	Entropy    float64
+	Cluster    int
+}
+
+func (s Scores) String() string {
+	return fmt.Sprintf("step %6d  var x %7.1f  var y %7.1f  entropy %5.3f  cluster %4d", s.Step, s.VarX, s.VarY, s.Entropy, s.Cluster)
+}
+
+// rankings order Scores from most to least picture-like, by name.
+var rankings = map[string]func(a, b Scores) int{
+	"variance": func(a, b Scores) int { return cmp.Compare(a.VarX*a.VarY, b.VarX*b.VarY) },
+	"entropy":  func(a, b Scores) int { return cmp.Compare(a.Entropy, b.Entropy) },
+	"cluster":  func(a, b Scores) int { return cmp.Compare(b.Cluster, a.Cluster) },
+}
+
+func scoreBoard(board Board, step int) Scores {
+	xs := make([]int, len(board.robots))
+	ys := make([]int, len(board.robots))
+	for i, r := range board.robots {
+		xs[i], ys[i] = r.position.X, r.position.Y
+	}
+	return Scores{
+		Step:    step,
+		VarX:    variance(xs),
+		VarY:    variance(ys),
+		Entropy: entropy(board),
+		Cluster: largestCluster(board),
+	}
+}
+
+func variance(values []int) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sum, sumSq := 0.0, 0.0
+	for _, v := range values {
+		sum += float64(v)
+		sumSq += float64(v) * float64(v)
+	}
+	n := float64(len(values))
+	mean := sum / n
+	return sumSq/n - mean*mean
+}
+
+// entropy is the Shannon entropy, in bits, of where the robots are when the
+// board is divided into ENTROPY_BLOCK-sized cells.
+func entropy(board Board) float64 {
+	cols := (board.width + ENTROPY_BLOCK - 1) / ENTROPY_BLOCK
+	counts := map[int]int{}
+	for _, r := range board.robots {
+		counts[r.position.Y/ENTROPY_BLOCK*cols+r.position.X/ENTROPY_BLOCK]++
+	}
+	n := float64(len(board.robots))
+	h := 0.0
+	for _, c := range counts {
+		p := float64(c) / n
+		h -= p * math.Log2(p)
+	}
+	return h
+}
+
+// largestCluster is the number of occupied tiles in the biggest group of
+// orthogonally adjacent occupied tiles.
+func largestCluster(board Board) int {
+	occupied := make([]bool, board.width*board.height)
+	for _, r := range board.robots {
+		occupied[r.position.Y*board.width+r.position.X] = true
+	}
+
+	largest := 0
+	stack := []int{}
+	for start := range occupied {
+		if !occupied[start] {
+			continue
+		}
+		occupied[start] = false
+		stack = append(stack[:0], start)
+		size := 0
+		for len(stack) > 0 {
+			i := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			size++
+			x, y := i%board.width, i/board.width
+			for _, n := range [][2]int{{x - 1, y}, {x + 1, y}, {x, y - 1}, {x, y + 1}} {
+				if n[0] < 0 || n[0] >= board.width || n[1] < 0 || n[1] >= board.height {
+					continue
+				}
+				j := n[1]*board.width + n[0]
+				if occupied[j] {
+					occupied[j] = false
+					stack = append(stack, j)
+				}
+			}
+		}
+		largest = max(largest, size)
+	}
+	return largest
+}
+
+// findPictureCRT finds the picture without simulating every step. Robots
+// return to the same x every width steps and the same y every height steps,
+// so the step where the x coordinates bunch up (the lowest x variance) is
+// found among the first width steps, the y one among the first height steps,
+// and the Chinese remainder theorem combines them into the step where both
+// happen at once.
+func findPictureCRT(board Board) (int, error) {
+	bestX, bestY := 0, 0
+	minVarX, minVarY := math.Inf(1), math.Inf(1)
+	xs := make([]int, len(board.robots))
+	ys := make([]int, len(board.robots))
+	for step := 0; step < max(board.width, board.height); step++ {
+		for i, r := range board.robots {
+			xs[i] = geom.Mod(r.position.X+r.velocity.X*step, board.width)
+			ys[i] = geom.Mod(r.position.Y+r.velocity.Y*step, board.height)
+		}
+		if v := variance(xs); step < board.width && v < minVarX {
+			bestX, minVarX = step, v
+		}
+		if v := variance(ys); step < board.height && v < minVarY {
+			bestY, minVarY = step, v
+		}
+	}
+
+	step, _, ok := crt(bestX, board.width, bestY, board.height)
+	if !ok {
+		return 0, fmt.Errorf("no step is %d mod %d and %d mod %d", bestX, board.width, bestY, board.height)
+	}
+	return step, nil
+}
+
+// scanSteps scores every step in one full cycle of the board (after which
+// the robots repeat) and returns the top k by the given ranking.
+func scanSteps(board Board, k int, rank func(a, b Scores) int) []Scores {
+	period := board.width / geom.GCD(board.width, board.height) * board.height
+	top := []Scores{}
+	for step := 0; step < period; step++ {
+		s := scoreBoard(board, step)
+		i, _ := slices.BinarySearchFunc(top, s, rank)
+		if i < k {
+			top = slices.Insert(top, i, s)
+			if len(top) > k {
+				top = top[:k]
+			}
+		}
+		advanceBoard(&board, 1)
+	}
+	return top
+}
+
+// crt returns the smallest non-negative x with x = a1 mod m1 and x = a2 mod
+// m2, along with lcm(m1, m2), or false if there is no such x.
+func crt(a1, m1, a2, m2 int) (int, int, bool) {
+	g, p, _ := geom.ExtendedGCD(m1, m2)
+	if (a2-a1)%g != 0 {
+		return 0, 0, false
+	}
+	lcm := m1 / g * m2
+	// x = a1 + m1*k, where m1*k = a2-a1 mod m2
+	k := geom.Mod((a2-a1)/g*p, m2/g)
+	return geom.Mod(a1+m1*k, lcm), lcm, true
+}
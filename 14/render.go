@@ -0,0 +1,120 @@
+package day14
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"io"
+
+	"github.com/faideww/aoc-2024/lib/geom"
+)
+
+// RenderOptions controls how board states are drawn.
+type RenderOptions struct {
+	// Scale is the width and height in pixels of each tile.
+	Scale int
+	// Quadrants shades the dividing rows and columns between the regions of
+	// Split (2x2 if left zero), which computeSafetyFactor leaves out, and
+	// draws robots standing on them in a different colour.
+	Quadrants bool
+	Split     Split
+	// Delay is the time between GIF frames, in hundredths of a second.
+	Delay int
+}
+
+// indexes into palette
+const (
+	colorEmpty = iota
+	colorRobot
+	colorDivider
+	colorUncounted
+)
+
+var palette = color.Palette{
+	color.Black,
+	color.RGBA{0x2e, 0xcc, 0x40, 0xff},
+	color.RGBA{0x30, 0x30, 0x40, 0xff},
+	color.RGBA{0xff, 0x85, 0x1b, 0xff},
+}
+
+// Frame draws the board as it is after the given number of steps, without
+// changing it.
+func Frame(board Board, step int, opts RenderOptions) (*image.Paletted, error) {
+	onDivider := func(x, y int) bool { return false }
+	if opts.Quadrants {
+		split := opts.Split
+		if split == (Split{}) {
+			split = Split{2, 2}
+		}
+		cols, err := splitAxis(board.width, split.Cols)
+		if err != nil {
+			return nil, err
+		}
+		rows, err := splitAxis(board.height, split.Rows)
+		if err != nil {
+			return nil, err
+		}
+		onDivider = func(x, y int) bool {
+			return cols.region(x) < 0 || rows.region(y) < 0
+		}
+	}
+
+	scale := max(opts.Scale, 1)
+	img := image.NewPaletted(image.Rect(0, 0, board.width*scale, board.height*scale), palette)
+
+	fill := func(x, y int, c uint8) {
+		for py := y * scale; py < (y+1)*scale; py++ {
+			for px := x * scale; px < (x+1)*scale; px++ {
+				img.SetColorIndex(px, py, c)
+			}
+		}
+	}
+	if opts.Quadrants {
+		for y := 0; y < board.height; y++ {
+			for x := 0; x < board.width; x++ {
+				if onDivider(x, y) {
+					fill(x, y, colorDivider)
+				}
+			}
+		}
+	}
+	for _, r := range board.robots {
+		p := r.position.Add(r.velocity.Scale(step))
+		x, y := geom.Mod(p.X, board.width), geom.Mod(p.Y, board.height)
+		c := uint8(colorRobot)
+		if onDivider(x, y) {
+			c = colorUncounted
+		}
+		fill(x, y, c)
+	}
+	return img, nil
+}
+
+// WritePNG writes the board after the given number of steps as a PNG.
+func WritePNG(w io.Writer, board Board, step int, opts RenderOptions) error {
+	img, err := Frame(board, step, opts)
+	if err != nil {
+		return err
+	}
+	return png.Encode(w, img)
+}
+
+// WriteGIF writes an animated GIF with one frame per step from "from" to
+// "to" inclusive.
+func WriteGIF(w io.Writer, board Board, from, to int, opts RenderOptions) error {
+	if to < from {
+		return fmt.Errorf("empty step range %d-%d", from, to)
+	}
+	anim := &gif.GIF{}
+	for step := from; step <= to; step++ {
+		img, err := Frame(board, step, opts)
+		if err != nil {
+			return err
+		}
+		anim.Image = append(anim.Image, img)
+		anim.Delay = append(anim.Delay, opts.Delay)
+	}
+	return gif.EncodeAll(w, anim)
+}
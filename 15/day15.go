@@ -0,0 +1,308 @@
+package day15
+
+import (
+	"fmt"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/grid"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+type Board struct {
+	tiles    *grid.Grid[rune]
+	robot    geom.Vec2
+	moves    []geom.Dir
+	nextMove int
+}
+
+func init() {
+	runner.Register(15, solver{})
+}
+
+type solver struct{}
+
+func (solver) Part1(input string) (runner.Answer, error) {
+	board, err := parseBoard(input)
+	if err != nil {
+		return nil, err
+	}
+	runRobot(board)
+	return runner.Int(scoreBoard(board)), nil
+}
+
+func (solver) Part2(input string) (runner.Answer, error) {
+	board, err := wideParseBoard(input)
+	if err != nil {
+		return nil, err
+	}
+	wideRunRobot(board)
+	return runner.Int(wideScoreBoard(board)), nil
+}
+
+// splitInput validates the warehouse map and move list, returning the map
+// lines and the concatenated moves. The map must be enclosed by walls, which
+// is what stops the robot and boxes leaving it.
+func splitInput(input string) ([]io.Line, []geom.Dir, error) {
+	sections := io.Sections(input)
+	if len(sections) != 2 {
+		return nil, nil, fmt.Errorf("expected a map and a move list separated by a blank line, got %d sections", len(sections))
+	}
+
+	lines := sections[0]
+	robots := 0
+	for y, line := range lines {
+		if len(line.Text) != len(lines[0].Text) {
+			return nil, nil, line.Errorf(0, "expected %d tiles, got %d", len(lines[0].Text), len(line.Text))
+		}
+		for x, char := range line.Text {
+			edge := y == 0 || y == len(lines)-1 || x == 0 || x == len(line.Text)-1
+			if edge && char != '#' {
+				return nil, nil, line.Errorf(x+1, "expected a wall (#) around the map, got %q", char)
+			}
+			switch char {
+			case '#', '.', 'O':
+			case '@':
+				robots++
+				if robots > 1 {
+					return nil, nil, line.Errorf(x+1, "found a second robot")
+				}
+			default:
+				return nil, nil, line.Errorf(x+1, "unexpected tile %q", char)
+			}
+		}
+	}
+	if robots == 0 {
+		return nil, nil, fmt.Errorf("no robot (@) found on the map")
+	}
+
+	moves := []geom.Dir{}
+	for _, line := range sections[1] {
+		for x, char := range line.Text {
+			move, err := geom.ParseArrow(char)
+			if err != nil {
+				return nil, nil, line.Errorf(x+1, "unexpected move %q", char)
+			}
+			moves = append(moves, move)
+		}
+	}
+
+	return lines, moves, nil
+}
+
+func parseBoard(input string) (Board, error) {
+	lines, moves, err := splitInput(input)
+	if err != nil {
+		return Board{}, err
+	}
+
+	// parse map
+	tiles := grid.New[rune](len(lines[0].Text), len(lines))
+
+	var robotPos geom.Vec2
+
+	for y, line := range lines {
+		for x, char := range line.Text {
+			pos := geom.Vec2{X: x, Y: y}
+			tiles.Set(pos, char)
+
+			if char == '@' {
+				robotPos = pos
+			}
+		}
+	}
+
+	return Board{
+		tiles:    tiles,
+		robot:    robotPos,
+		moves:    moves,
+		nextMove: 0,
+	}, nil
+}
+
+func runRobot(board Board) {
+	for board.nextMove < len(board.moves) {
+		nextMove := board.moves[board.nextMove]
+		board.nextMove++
+
+		// attempt to move the robot
+		delta := nextMove.Vec()
+
+		current := board.robot
+		dest := current.Add(delta)
+		canMove := true
+		for board.tiles.Get(dest) != '.' {
+			// advance each tile one 'delta' forward from current
+			if board.tiles.Get(dest) == '#' {
+				canMove = false
+				break
+			}
+			current = dest
+			dest = current.Add(delta)
+		}
+
+		if canMove {
+			// walk back from the dest to the robot, and shift all tiles forward
+
+			current := dest
+			for {
+				// swap the tiles at current and current-reverseDelta
+				prev := current.Sub(delta)
+				board.tiles.Swap(current, prev)
+
+				if board.tiles.Get(current) == '@' {
+					board.robot = current
+					break
+				}
+
+				current = prev
+			}
+		}
+	}
+}
+
+func printBoard(board Board) {
+	fmt.Print(board.tiles)
+}
+
+func scoreBoard(board Board) int {
+	sum := 0
+	for pos, tile := range board.tiles.All() {
+		if tile == 'O' {
+			sum += pos.Y*100 + pos.X
+		}
+	}
+
+	return sum
+}
+
+func wideParseBoard(input string) (Board, error) {
+	lines, moves, err := splitInput(input)
+	if err != nil {
+		return Board{}, err
+	}
+
+	// parse map
+	tiles := grid.New[rune](len(lines[0].Text)*2, len(lines))
+
+	var robotPos geom.Vec2
+
+	for y, line := range lines {
+		for lx, char := range line.Text {
+			pos := geom.Vec2{X: lx * 2, Y: y}
+			pos2 := geom.Vec2{X: (lx * 2) + 1, Y: y}
+			switch char {
+			case '#':
+				tiles.Set(pos, '#')
+				tiles.Set(pos2, '#')
+			case '.':
+				tiles.Set(pos, '.')
+				tiles.Set(pos2, '.')
+			case 'O':
+				tiles.Set(pos, '[')
+				tiles.Set(pos2, ']')
+			case '@':
+				tiles.Set(pos, '@')
+				tiles.Set(pos2, '.')
+				robotPos = pos
+			}
+		}
+	}
+
+	return Board{
+		tiles:    tiles,
+		robot:    robotPos,
+		moves:    moves,
+		nextMove: 0,
+	}, nil
+}
+
+func wideRunRobot(board Board) {
+	for board.nextMove < len(board.moves) {
+		nextMove := board.moves[board.nextMove]
+		board.nextMove++
+
+		// attempt to move the robot
+		delta := nextMove.Vec()
+
+		// walk forward, "staging" each block for movement. if any block is not movable, the entire move is cancelled
+		if nextMove == geom.North || nextMove == geom.South {
+			// vertical movement is a special case; we need to make sure we deal with double-wide boxes appropriately
+			if isTileMovable(board, board.robot, delta) {
+				commitTileMovement(board, board.robot, delta)
+				board.robot = board.robot.Add(delta)
+			}
+		} else {
+			current := board.robot
+			dest := current.Add(delta)
+			canMove := true
+			for board.tiles.Get(dest) != '.' {
+				// advance each tile one 'delta' forward from current
+				if board.tiles.Get(dest) == '#' {
+					canMove = false
+					break
+				}
+				current = dest
+				dest = current.Add(delta)
+			}
+
+			if canMove {
+				// walk back from the dest to the robot, and shift all tiles forward
+
+				current := dest
+				for {
+					// swap the tiles at current and current-reverseDelta
+					prev := current.Sub(delta)
+					board.tiles.Swap(current, prev)
+
+					if board.tiles.Get(current) == '@' {
+						board.robot = current
+						break
+					}
+
+					current = prev
+				}
+			}
+		}
+		// printBoard(board)
+	}
+}
+
+func isTileMovable(board Board, tile geom.Vec2, delta geom.Vec2) bool {
+	nextPos := tile.Add(delta)
+	if board.tiles.Get(nextPos) == '.' {
+		return true
+	} else if board.tiles.Get(nextPos) == '#' {
+		return false
+	} else if board.tiles.Get(nextPos) == '[' {
+		return isTileMovable(board, nextPos, delta) && isTileMovable(board, nextPos.Add(geom.East.Vec()), delta)
+	} else if board.tiles.Get(nextPos) == ']' {
+		return isTileMovable(board, nextPos.Add(geom.West.Vec()), delta) && isTileMovable(board, nextPos, delta)
+	}
+
+	// should be unreachable
+	return false
+}
+
+func commitTileMovement(board Board, tile geom.Vec2, delta geom.Vec2) {
+	nextPos := tile.Add(delta)
+	if board.tiles.Get(nextPos) == '[' {
+		commitTileMovement(board, nextPos, delta)
+		commitTileMovement(board, nextPos.Add(geom.East.Vec()), delta)
+	} else if board.tiles.Get(nextPos) == ']' {
+		commitTileMovement(board, nextPos.Add(geom.West.Vec()), delta)
+		commitTileMovement(board, nextPos, delta)
+	}
+	board.tiles.Swap(tile, nextPos)
+}
+
+func wideScoreBoard(board Board) int {
+	sum := 0
+	for pos, tile := range board.tiles.All() {
+		if tile == '[' {
+			sum += pos.Y*100 + pos.X
+		}
+	}
+
+	return sum
+}
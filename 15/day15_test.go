@@ -0,0 +1,38 @@
+package day15
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner"
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "10092", Part2: "9021"},
+		{Input: "test2.txt", Part1: "2028", Part2: "1751"},
+		{Input: "test3.txt", Part1: "908", Part2: "618"},
+		{Input: "in.txt", Part1: "1485257", Part2: "1475512"},
+	})
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, input, want string
+	}{
+		{"no walls", ".@O.\n\n<<<^^>>>>>>", "1:1: expected a wall (#) around the map, got '.'"},
+		{"gap in the side", "####\n#@O.\n####\n\n>>", "2:4: expected a wall (#) around the map, got '.'"},
+		{"ragged", "####\n#@O#\n###\n\n<", "3: expected 4 tiles, got 3"},
+		{"two robots", "####\n#@@#\n####\n\n<", "2:3: found a second robot"},
+		{"bad move", "###\n#@#\n###\n\n<x", "5:2: unexpected move 'x'"},
+	}
+	for _, tt := range tests {
+		for part := 1; part <= 2; part++ {
+			_, err := runner.Solve(solver{}, part, tt.input)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("%s: part %d: got %v, want an error containing %q", tt.name, part, err, tt.want)
+			}
+		}
+	}
+}
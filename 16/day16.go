@@ -0,0 +1,282 @@
+package day16
+
+import (
+	"flag"
+	"fmt"
+	stdio "io"
+	"os"
+
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/grid"
+	"github.com/faideww/aoc-2024/lib/runner"
+	"github.com/faideww/aoc-2024/lib/search"
+)
+
+type Position2 struct {
+	tile geom.Vec2
+	dir  geom.Dir
+}
+
+type Board struct {
+	tiles  *grid.Grid[rune]
+	starts []geom.Vec2
+	goals  map[geom.Vec2]bool
+}
+
+// Rules are what moving through the maze costs and which way the reindeer
+// must face at each end of a route.
+type Rules struct {
+	stepCost, turnCost int
+	startDir           geom.Dir
+	// endDir is the heading a route must finish on, unless anyEndDir is set
+	endDir    geom.Dir
+	anyEndDir bool
+}
+
+// Route is one cheapest way through the maze, with every state visited.
+type Route struct {
+	cost int
+	path []Position2
+}
+
+func init() {
+	runner.Register(16, newSolver())
+}
+
+func newSolver() *solver {
+	return &solver{rules: Rules{
+		stepCost:  1,
+		turnCost:  1000,
+		startDir:  geom.East,
+		anyEndDir: true,
+	}}
+}
+
+type solver struct {
+	rules    Rules
+	show     bool
+	paths    int
+	contract bool
+	analyze  bool
+}
+
+// Configure accepts the cost of a step (-step) and a quarter turn (-turn),
+// the heading the reindeer starts on (-face, one of N E S W) and the one it
+// must finish on (-end, or "any"). -show draws the routes found on stderr,
+// and in part 2 -paths counts the cheapest routes and draws up to that many
+// of them.
+//
+// -contract searches the maze contracted to its junctions and corridors
+// instead of tile by tile, and -analyze reports on the maze's shape in
+// part 1.
+func (s *solver) Configure(args []string) error {
+	fs := flag.NewFlagSet("day 16", flag.ContinueOnError)
+	fs.IntVar(&s.rules.stepCost, "step", s.rules.stepCost, "cost of moving forward one tile")
+	fs.IntVar(&s.rules.turnCost, "turn", s.rules.turnCost, "cost of turning 90 degrees")
+	face := fs.String("face", s.rules.startDir.String(), "heading at the start: N, E, S or W")
+	end := fs.String("end", "any", "heading required at the end: N, E, S, W or any")
+	fs.BoolVar(&s.show, "show", s.show, "draw the routes found on stderr")
+	fs.IntVar(&s.paths, "paths", s.paths, "part 2: count the cheapest routes and draw up to this many")
+	fs.BoolVar(&s.contract, "contract", s.contract, "search the maze contracted to junctions and corridors")
+	fs.BoolVar(&s.analyze, "analyze", s.analyze, "part 1: report dead ends, junctions, corridors and loops")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if s.paths < 0 {
+		return fmt.Errorf("-paths must not be negative, got %d", s.paths)
+	}
+	if s.rules.stepCost < 0 || s.rules.turnCost < 0 {
+		return fmt.Errorf("costs must not be negative")
+	}
+	var err error
+	if s.rules.startDir, err = parseHeading(*face); err != nil {
+		return err
+	}
+	s.rules.anyEndDir = *end == "any"
+	if !s.rules.anyEndDir {
+		if s.rules.endDir, err = parseHeading(*end); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func parseHeading(s string) (geom.Dir, error) {
+	if len(s) != 1 {
+		return 0, fmt.Errorf("expected a heading N, E, S or W, got %q", s)
+	}
+	return geom.ParseCompass(rune(s[0]))
+}
+
+func (s *solver) Part1(input string) (runner.Answer, error) {
+	board, err := parseBoard(input)
+	if err != nil {
+		return nil, err
+	}
+	var route Route
+	var ok bool
+	if s.contract || s.analyze {
+		g := contractMaze(board)
+		if s.analyze {
+			fmt.Fprint(os.Stderr, analyzeMaze(board, g))
+		}
+		route, ok = findCheapestRouteContracted(board, g, s.rules)
+	} else {
+		route, ok = findCheapestRoute(board, s.rules)
+	}
+	if !ok {
+		return nil, fmt.Errorf("no route reaches an end tile")
+	}
+	if s.show {
+		printBoard(os.Stderr, board, pathMarks(route.path))
+	}
+	return runner.Int(route.cost), nil
+}
+
+func (s *solver) Part2(input string) (runner.Answer, error) {
+	board, err := parseBoard(input)
+	if err != nil {
+		return nil, err
+	}
+	var routes search.Optimal[Position2]
+	var states []Position2
+	var ok bool
+	expand := func(path []Position2) []Position2 { return path }
+	if s.contract {
+		g := contractMaze(board)
+		routes, states, ok = findAllCheapestRoutesContracted(board, g, s.rules)
+		expand = func(path []Position2) []Position2 { return g.expand(path, s.rules) }
+	} else {
+		routes, ok = findAllCheapestRoutes(board, s.rules)
+		states = routes.Nodes()
+	}
+	if !ok {
+		return nil, fmt.Errorf("no route reaches an end tile")
+	}
+
+	onPathTiles := map[geom.Vec2]rune{}
+	for _, pos := range states {
+		onPathTiles[pos.tile] = 'O'
+	}
+	if s.show {
+		printBoard(os.Stderr, board, onPathTiles)
+	}
+	if s.paths > 0 {
+		fmt.Fprintf(os.Stderr, "%s cheapest routes, costing %d\n", routes.CountPaths(), routes.Cost)
+		for i, path := range routes.Paths(s.paths) {
+			fmt.Fprintf(os.Stderr, "\nroute %d:\n", i+1)
+			printBoard(os.Stderr, board, pathMarks(expand(path)))
+		}
+	}
+	return runner.Int(len(onPathTiles)), nil
+}
+
+// pathMarks marks each tile on a route with the heading it is left on.
+func pathMarks(path []Position2) map[geom.Vec2]rune {
+	marks := map[geom.Vec2]rune{}
+	for _, pos := range path {
+		marks[pos.tile] = pos.dir.Arrow()
+	}
+	return marks
+}
+
+// parseBoard reads the maze, which may have several start (S) and end (E)
+// tiles. A route may begin at any start and finish at any end.
+func parseBoard(input string) (Board, error) {
+	starts := []geom.Vec2{}
+	goals := map[geom.Vec2]bool{}
+	tiles, err := grid.ParseFunc(input, func(pos geom.Vec2, char rune) (rune, error) {
+		switch char {
+		case '#', '.':
+		case 'S':
+			starts = append(starts, pos)
+		case 'E':
+			goals[pos] = true
+		default:
+			return 0, fmt.Errorf("unexpected tile %q", char)
+		}
+		return char, nil
+	})
+	if err != nil {
+		return Board{}, err
+	}
+	if len(starts) == 0 || len(goals) == 0 {
+		return Board{}, fmt.Errorf("maze needs both a start (S) and an end (E) tile")
+	}
+	return Board{
+		tiles,
+		starts,
+		goals,
+	}, nil
+}
+
+// startStates are the states a route may begin in: every start tile, facing
+// the starting heading.
+func (board Board) startStates(rules Rules) []Position2 {
+	states := make([]Position2, len(board.starts))
+	for i, tile := range board.starts {
+		states[i] = Position2{tile, rules.startDir}
+	}
+	return states
+}
+
+func findCheapestRoute(board Board, rules Rules) (Route, bool) {
+	neighbors := func(pos Position2) []search.Edge[Position2] {
+		return findNeighbors(board.tiles, pos, rules)
+	}
+
+	result, ok := search.DijkstraFrom(board.startStates(rules), neighbors, board.isGoal(rules))
+	if !ok {
+		return Route{}, false
+	}
+	return Route{result.Cost, result.Path()}, true
+}
+
+// findAllCheapestRoutes finds every cheapest route at once. Rather than
+// carrying paths around, the search records each state's predecessors on
+// cheapest routes, forming a DAG that can be walked back from the goals.
+func findAllCheapestRoutes(board Board, rules Rules) (search.Optimal[Position2], bool) {
+	neighbors := func(pos Position2) []search.Edge[Position2] {
+		return findNeighbors(board.tiles, pos, rules)
+	}
+	return search.AllOptimalFrom(board.startStates(rules), neighbors, board.isGoal(rules))
+}
+
+// isGoal returns a check for a state that finishes a route: on an end tile,
+// and facing the required heading if there is one.
+func (board Board) isGoal(rules Rules) func(Position2) bool {
+	return func(pos Position2) bool {
+		return board.goals[pos.tile] && (rules.anyEndDir || pos.dir == rules.endDir)
+	}
+}
+
+func findNeighbors(tiles *grid.Grid[rune], pos Position2, rules Rules) []search.Edge[Position2] {
+	neighbors := make([]search.Edge[Position2], 0, 3)
+
+	nextPos := pos.tile.Add(pos.dir.Vec())
+	if isOpen(tiles, nextPos) {
+		neighbors = append(neighbors, search.Edge[Position2]{To: Position2{nextPos, pos.dir}, Cost: rules.stepCost})
+	}
+
+	neighbors = append(neighbors, search.Edge[Position2]{To: Position2{pos.tile, pos.dir.TurnLeft()}, Cost: rules.turnCost})
+	neighbors = append(neighbors, search.Edge[Position2]{To: Position2{pos.tile, pos.dir.TurnRight()}, Cost: rules.turnCost})
+
+	return neighbors
+}
+
+// printBoard draws the maze, replacing any tile that has a mark with it.
+func printBoard(w stdio.Writer, board Board, marks map[geom.Vec2]rune) {
+	fmt.Fprint(w, board.tiles.Format(func(pos geom.Vec2, tile rune) string {
+		if mark, ok := marks[pos]; ok {
+			return string(mark)
+		}
+		return string(tile)
+	}))
+}
+
+// isOpen reports whether pos is a walkable tile inside the maze.
+func isOpen(tiles *grid.Grid[rune], pos geom.Vec2) bool {
+	tile, ok := tiles.Lookup(pos)
+	return ok && tile != '#'
+}
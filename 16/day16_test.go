@@ -0,0 +1,240 @@
+package day16
+
+import (
+	"container/heap"
+	"slices"
+	"testing"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/runner"
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, newSolver, []runnertest.Case{
+		{Input: "test.txt", Part1: "7036", Part2: "45"},
+		{Input: "test2.txt", Part1: "11048", Part2: "64"},
+		{Input: "in.txt", Part1: "109516", Part2: "568"},
+	})
+}
+
+// loopMaze has two routes from S to E of six steps each: along the bottom
+// and up, turning once, or up and along the top, turning twice.
+const loopMaze = `#######
+#....E#
+#.###.#
+#S....#
+#######`
+
+// twoEndsMaze has an end three steps either side of the start.
+const twoEndsMaze = `#########
+#E..S..E#
+#########`
+
+// twoStartsMaze has one start four steps from the end in a straight line,
+// and another six steps and a turn away.
+const twoStartsMaze = `#######
+#S...E#
+#####.#
+#S....#
+#######`
+
+func TestRules(t *testing.T) {
+	tests := []struct {
+		name         string
+		maze         string
+		args         []string
+		part1, part2 int
+	}{
+		{"default", loopMaze, nil, 1006, 7},
+		{"cheap turns", loopMaze, []string{"-turn", "1"}, 7, 7},
+		{"free turns", loopMaze, []string{"-turn", "0"}, 6, 12},
+		{"costly steps", loopMaze, []string{"-step", "10", "-turn", "1"}, 61, 7},
+		{"facing north", loopMaze, []string{"-face", "N", "-turn", "5"}, 11, 7},
+		{"ending west", loopMaze, []string{"-end", "W"}, 2006, 7},
+		{"ending south", loopMaze, []string{"-end", "S"}, 3006, 12},
+		{"ending north", loopMaze, []string{"-end", "N"}, 1006, 7},
+		{"nearer end", twoEndsMaze, nil, 3, 4},
+		{"ends either side", twoEndsMaze, []string{"-face", "N"}, 1003, 7},
+		{"nearer start", twoStartsMaze, nil, 4, 5},
+		{"starts facing south", twoStartsMaze, []string{"-face", "S"}, 1004, 5},
+	}
+	for _, tt := range tests {
+		s := newSolver()
+		if err := s.Configure(tt.args); err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		for i, want := range []int{tt.part1, tt.part2} {
+			part := i + 1
+			got, err := runner.Solve(s, part, tt.maze)
+			if err != nil {
+				t.Errorf("%s: part %d: %v", tt.name, part, err)
+			} else if got != runner.Int(want) {
+				t.Errorf("%s: part %d: got %s, want %d", tt.name, part, got, want)
+			}
+		}
+	}
+}
+
+func TestConfigureErrors(t *testing.T) {
+	for _, args := range [][]string{
+		{"-step", "-1"},
+		{"-turn", "-1"},
+		{"-face", "X"},
+		{"-face", "NE"},
+		{"-end", "up"},
+		{"-paths", "-1"},
+	} {
+		if err := newSolver().Configure(args); err == nil {
+			t.Errorf("Configure(%q) succeeded, want an error", args)
+		}
+	}
+}
+
+func TestRoutePath(t *testing.T) {
+	board, err := parseBoard(loopMaze)
+	if err != nil {
+		t.Fatal(err)
+	}
+	route, ok := findCheapestRoute(board, newSolver().rules)
+	if !ok {
+		t.Fatal("no route found")
+	}
+	want := []Position2{
+		{geom.Vec2{X: 1, Y: 3}, geom.East},
+		{geom.Vec2{X: 2, Y: 3}, geom.East},
+		{geom.Vec2{X: 3, Y: 3}, geom.East},
+		{geom.Vec2{X: 4, Y: 3}, geom.East},
+		{geom.Vec2{X: 5, Y: 3}, geom.East},
+		{geom.Vec2{X: 5, Y: 3}, geom.North},
+		{geom.Vec2{X: 5, Y: 2}, geom.North},
+		{geom.Vec2{X: 5, Y: 1}, geom.North},
+	}
+	if route.cost != 1006 || !slices.Equal(route.path, want) {
+		t.Errorf("got route costing %d via %v, want 1006 via %v", route.cost, route.path, want)
+	}
+}
+
+// heapQueue is the container/heap based queue that io.PriorityQueue
+// replaced, kept here so the two can be benchmarked against each other.
+type heapQueue []*io.PQItem[Position2]
+
+func (pq heapQueue) Len() int { return len(pq) }
+
+func (pq heapQueue) Less(i, j int) bool { return pq[i].Priority < pq[j].Priority }
+
+func (pq heapQueue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].Index = i
+	pq[j].Index = j
+}
+
+func (pq *heapQueue) Push(x any) {
+	item := x.(*io.PQItem[Position2])
+	item.Index = len(*pq)
+	*pq = append(*pq, item)
+}
+
+func (pq *heapQueue) Pop() any {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	old[n-1] = nil
+	item.Index = -1
+	*pq = old[0 : n-1]
+	return item
+}
+
+// heapCheapestRoute is findCheapestRoute written directly against heapQueue,
+// pushing duplicates and skipping stale entries as the old code did.
+func heapCheapestRoute(board Board) int {
+	rules := newSolver().rules
+	start := board.startStates(rules)[0]
+	isGoal := board.isGoal(rules)
+	frontier := heapQueue{}
+	heap.Push(&frontier, &io.PQItem[Position2]{Value: start})
+	costSoFar := map[Position2]int{start: 0}
+
+	for frontier.Len() > 0 {
+		item := heap.Pop(&frontier).(*io.PQItem[Position2])
+		if item.Priority > costSoFar[item.Value] {
+			continue
+		}
+		if isGoal(item.Value) {
+			return item.Priority
+		}
+		for _, edge := range findNeighbors(board.tiles, item.Value, rules) {
+			newCost := item.Priority + edge.Cost
+			if oldCost, ok := costSoFar[edge.To]; !ok || newCost < oldCost {
+				costSoFar[edge.To] = newCost
+				heap.Push(&frontier, &io.PQItem[Position2]{Value: edge.To, Priority: newCost})
+			}
+		}
+	}
+	return -1
+}
+
+// queueCheapestRoute is heapCheapestRoute using io.PriorityQueue, so the
+// two differ only in the queue.
+func queueCheapestRoute(board Board) int {
+	rules := newSolver().rules
+	start := board.startStates(rules)[0]
+	isGoal := board.isGoal(rules)
+	frontier := io.NewMinQueue[Position2]()
+	frontier.Push(start, 0)
+	costSoFar := map[Position2]int{start: 0}
+
+	for frontier.Len() > 0 {
+		current, cost := frontier.Pop()
+		if cost > costSoFar[current] {
+			continue
+		}
+		if isGoal(current) {
+			return cost
+		}
+		for _, edge := range findNeighbors(board.tiles, current, rules) {
+			newCost := cost + edge.Cost
+			if oldCost, ok := costSoFar[edge.To]; !ok || newCost < oldCost {
+				costSoFar[edge.To] = newCost
+				frontier.Push(edge.To, newCost)
+			}
+		}
+	}
+	return -1
+}
+
+func BenchmarkCheapestRoute(b *testing.B) {
+	input, err := io.ReadInputFile("in.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	board, err := parseBoard(input)
+	if err != nil {
+		b.Fatal(err)
+	}
+	searchCheapestRoute := func(board Board) int {
+		route, _ := findCheapestRoute(board, newSolver().rules)
+		return route.cost
+	}
+	want := searchCheapestRoute(board)
+
+	for _, bm := range []struct {
+		name  string
+		route func(Board) int
+	}{
+		{"search.Dijkstra", searchCheapestRoute},
+		{"PriorityQueue", queueCheapestRoute},
+		{"container/heap", heapCheapestRoute},
+	} {
+		b.Run(bm.name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if got := bm.route(board); got != want {
+					b.Fatalf("got %d, want %d", got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package day16
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/search"
+)
+
+// Corridor is a stretch of maze between two nodes with no choices along the
+// way. It may bend, but every tile inside it has exactly two open
+// neighbours.
+type Corridor struct {
+	from, to geom.Vec2
+	// arrive is the heading the reindeer has on reaching to
+	arrive        geom.Dir
+	length, turns int
+	// path holds each tile inside the corridor and the heading it is left
+	// on, followed by the state it arrives in at to
+	path []Position2
+}
+
+func (c Corridor) cost(rules Rules) int {
+	return c.length*rules.stepCost + c.turns*rules.turnCost
+}
+
+// MazeGraph is the maze contracted to its nodes (junctions, dead ends and
+// the start and end tiles) and the corridors joining them.
+type MazeGraph struct {
+	nodes map[geom.Vec2]bool
+	// exits maps a node and the heading leaving it to the corridor taken
+	exits map[Position2]Corridor
+}
+
+// isNode reports whether an open tile must be kept when contracting the
+// maze: anything that isn't a plain corridor tile with two ways out.
+func (board Board) isNode(tile geom.Vec2) bool {
+	if board.goals[tile] || board.tiles.Get(tile) == 'S' {
+		return true
+	}
+	return len(board.exitsFrom(tile)) != 2
+}
+
+func (board Board) exitsFrom(tile geom.Vec2) []geom.Dir {
+	exits := []geom.Dir{}
+	for _, d := range geom.Dirs {
+		if isOpen(board.tiles, tile.Add(d.Vec())) {
+			exits = append(exits, d)
+		}
+	}
+	return exits
+}
+
+func contractMaze(board Board) MazeGraph {
+	g := MazeGraph{nodes: map[geom.Vec2]bool{}, exits: map[Position2]Corridor{}}
+	for tile, char := range board.tiles.All() {
+		if char != '#' && board.isNode(tile) {
+			g.nodes[tile] = true
+		}
+	}
+	for node := range g.nodes {
+		for _, d := range board.exitsFrom(node) {
+			g.exits[Position2{node, d}] = board.followCorridor(node, d, g.nodes)
+		}
+	}
+	return g
+}
+
+// followCorridor walks from a node in direction dir until it reaches the
+// next node.
+func (board Board) followCorridor(from geom.Vec2, dir geom.Dir, nodes map[geom.Vec2]bool) Corridor {
+	c := Corridor{from: from}
+	tile := from.Add(dir.Vec())
+	c.length = 1
+	for !nodes[tile] {
+		// the one way on that isn't back the way we came
+		for _, d := range board.exitsFrom(tile) {
+			if d != dir.Reverse() {
+				if d != dir {
+					c.turns++
+				}
+				dir = d
+				break
+			}
+		}
+		c.path = append(c.path, Position2{tile, dir})
+		tile = tile.Add(dir.Vec())
+		c.length++
+	}
+	c.to, c.arrive = tile, dir
+	c.path = append(c.path, Position2{tile, dir})
+	return c
+}
+
+// neighbors are the moves from a node state: turning on the spot, or
+// following the corridor ahead to the next node.
+func (g MazeGraph) neighbors(rules Rules) func(Position2) []search.Edge[Position2] {
+	return func(pos Position2) []search.Edge[Position2] {
+		neighbors := make([]search.Edge[Position2], 0, 3)
+		if c, ok := g.exits[pos]; ok {
+			neighbors = append(neighbors, search.Edge[Position2]{To: Position2{c.to, c.arrive}, Cost: c.cost(rules)})
+		}
+		neighbors = append(neighbors, search.Edge[Position2]{To: Position2{pos.tile, pos.dir.TurnLeft()}, Cost: rules.turnCost})
+		neighbors = append(neighbors, search.Edge[Position2]{To: Position2{pos.tile, pos.dir.TurnRight()}, Cost: rules.turnCost})
+		return neighbors
+	}
+}
+
+// crosses reports whether a cheapest move from one node state to the next
+// follows the corridor leaving from, rather than turning on the spot. A
+// corridor can loop back to the node it leaves, arriving in the same state
+// as a turn would; then it is taken only if it costs no more than turning.
+func (g MazeGraph) crosses(from, to Position2, rules Rules) bool {
+	c, ok := g.exits[from]
+	if !ok || to != (Position2{c.to, c.arrive}) {
+		return false
+	}
+	if to.tile == from.tile && (to.dir == from.dir.TurnLeft() || to.dir == from.dir.TurnRight()) {
+		return c.cost(rules) <= rules.turnCost
+	}
+	return true
+}
+
+// expand fills in the corridor tiles between consecutive node states of a
+// route found on the contracted graph.
+func (g MazeGraph) expand(path []Position2, rules Rules) []Position2 {
+	full := []Position2{}
+	for i, pos := range path {
+		full = append(full, pos)
+		if i+1 < len(path) && g.crosses(pos, path[i+1], rules) {
+			full = append(full, g.exits[pos].path[:len(g.exits[pos].path)-1]...)
+		}
+	}
+	return full
+}
+
+// findCheapestRouteContracted is findCheapestRoute run on the contracted
+// maze. It visits only node states, so on mazes made of long corridors it
+// searches far fewer states.
+func findCheapestRouteContracted(board Board, g MazeGraph, rules Rules) (Route, bool) {
+	result, ok := search.DijkstraFrom(board.startStates(rules), g.neighbors(rules), board.isGoal(rules))
+	if !ok {
+		return Route{}, false
+	}
+	return Route{result.Cost, g.expand(result.Path(), rules)}, true
+}
+
+// findAllCheapestRoutesContracted is findAllCheapestRoutes run on the
+// contracted maze, returning the states on a cheapest route along with the
+// corridor tiles between them.
+func findAllCheapestRoutesContracted(board Board, g MazeGraph, rules Rules) (search.Optimal[Position2], []Position2, bool) {
+	routes, ok := search.AllOptimalFrom(board.startStates(rules), g.neighbors(rules), board.isGoal(rules))
+	if !ok {
+		return routes, nil, false
+	}
+	states := []Position2{}
+	for _, pos := range routes.Nodes() {
+		states = append(states, pos)
+		for _, pred := range routes.Predecessors(pos) {
+			if g.crosses(pred, pos, rules) {
+				states = append(states, g.exits[pred].path...)
+			}
+		}
+	}
+	return routes, states, true
+}
+
+// Analysis summarises the shape of a maze.
+type Analysis struct {
+	OpenTiles, Nodes         int
+	Junctions, DeadEnds      int
+	Corridors, Loops         int
+	Branching                float64
+	MinCorridor, MaxCorridor int
+	MeanCorridor             float64
+}
+
+func analyzeMaze(board Board, g MazeGraph) Analysis {
+	a := Analysis{Nodes: len(g.nodes), MinCorridor: -1}
+	for tile, char := range board.tiles.All() {
+		if char != '#' {
+			a.OpenTiles++
+		}
+		if !g.nodes[tile] {
+			continue
+		}
+		switch exits := len(board.exitsFrom(tile)); {
+		case exits >= 3:
+			a.Junctions++
+			a.Branching += float64(exits)
+		case exits == 1 && char == '.':
+			a.DeadEnds++
+		}
+	}
+	if a.Junctions > 0 {
+		a.Branching /= float64(a.Junctions)
+	}
+
+	// every corridor is in exits once from each end
+	total := 0
+	for _, c := range g.exits {
+		a.Corridors++
+		total += c.length
+		if a.MinCorridor < 0 || c.length < a.MinCorridor {
+			a.MinCorridor = c.length
+		}
+		a.MaxCorridor = max(a.MaxCorridor, c.length)
+	}
+	a.Corridors /= 2
+	if a.Corridors > 0 {
+		a.MeanCorridor = float64(total) / float64(2*a.Corridors)
+	}
+
+	// independent loops: corridors - nodes + connected components
+	a.Loops = a.Corridors - a.Nodes + g.components()
+	return a
+}
+
+func (g MazeGraph) components() int {
+	parent := map[geom.Vec2]geom.Vec2{}
+	var find func(n geom.Vec2) geom.Vec2
+	find = func(n geom.Vec2) geom.Vec2 {
+		if p, ok := parent[n]; ok && p != n {
+			parent[n] = find(p)
+			return parent[n]
+		}
+		return n
+	}
+	components := len(g.nodes)
+	for _, c := range g.exits {
+		if a, b := find(c.from), find(c.to); a != b {
+			parent[a] = b
+			components--
+		}
+	}
+	return components
+}
+
+func (a Analysis) String() string {
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "open tiles:   %d (%d search states)\n", a.OpenTiles, 4*a.OpenTiles)
+	fmt.Fprintf(&b, "nodes:        %d (%d search states)\n", a.Nodes, 4*a.Nodes)
+	fmt.Fprintf(&b, "junctions:    %d, %.2f exits on average\n", a.Junctions, a.Branching)
+	fmt.Fprintf(&b, "dead ends:    %d\n", a.DeadEnds)
+	fmt.Fprintf(&b, "corridors:    %d, %d-%d tiles long, %.1f on average\n", a.Corridors, a.MinCorridor, a.MaxCorridor, a.MeanCorridor)
+	fmt.Fprintf(&b, "loops:        %d\n", a.Loops)
+	return b.String()
+}
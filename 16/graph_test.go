@@ -0,0 +1,199 @@
+package day16
+
+import (
+	"maps"
+	"slices"
+	"testing"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/geom"
+)
+
+// TestContractedMatchesTiles checks that searching the contracted maze finds
+// the same cheapest cost, number of cheapest routes and tiles on them as
+// searching tile by tile.
+func TestContractedMatchesTiles(t *testing.T) {
+	ruleSets := map[string][]string{
+		"default":     nil,
+		"cheap turns": {"-turn", "1"},
+		"free turns":  {"-turn", "0"},
+		"free steps":  {"-step", "0"},
+		"north south": {"-face", "N", "-end", "S"},
+		"west east":   {"-face", "W", "-end", "E", "-step", "3", "-turn", "7"},
+	}
+	for _, file := range []string{"test.txt", "test2.txt", "in.txt"} {
+		input, err := io.ReadInputFile(file)
+		if err != nil {
+			t.Fatal(err)
+		}
+		board, err := parseBoard(input)
+		if err != nil {
+			t.Fatal(err)
+		}
+		g := contractMaze(board)
+		for name, args := range ruleSets {
+			s := newSolver()
+			if err := s.Configure(args); err != nil {
+				t.Fatal(err)
+			}
+
+			route, ok := findCheapestRoute(board, s.rules)
+			contracted, cok := findCheapestRouteContracted(board, g, s.rules)
+			if ok != cok || route.cost != contracted.cost {
+				t.Errorf("%s, %s: tile search costs %d (%t), contracted %d (%t)", file, name, route.cost, ok, contracted.cost, cok)
+				continue
+			}
+
+			routes, _ := findAllCheapestRoutes(board, s.rules)
+			croutes, states, _ := findAllCheapestRoutesContracted(board, g, s.rules)
+			// with free moves the count depends on the order states are
+			// settled in, which the two searches don't share
+			free := s.rules.turnCost == 0 || s.rules.stepCost == 0
+			if got, want := croutes.CountPaths(), routes.CountPaths(); !free && got.Cmp(want) != 0 {
+				t.Errorf("%s, %s: contracted search counts %s cheapest routes, want %s", file, name, got, want)
+			}
+			if got, want := tilesOf(states), tilesOf(routes.Nodes()); !maps.Equal(got, want) {
+				t.Errorf("%s, %s: contracted routes cover %d tiles, want %d", file, name, len(got), len(want))
+			}
+		}
+	}
+}
+
+func tilesOf(states []Position2) map[geom.Vec2]bool {
+	tiles := map[geom.Vec2]bool{}
+	for _, pos := range states {
+		tiles[pos.tile] = true
+	}
+	return tiles
+}
+
+func TestExpand(t *testing.T) {
+	board, err := parseBoard(loopMaze)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := contractMaze(board)
+	start, end := geom.Vec2{X: 1, Y: 3}, geom.Vec2{X: 5, Y: 1}
+
+	tests := []struct {
+		name string
+		path []Position2
+		want []Position2
+	}{
+		{
+			name: "along the bottom",
+			path: []Position2{{start, geom.East}, {end, geom.North}},
+			// corridor tiles carry the heading they are left on, so the turn
+			// at the corner shows as the tile being left north
+			want: []Position2{
+				{start, geom.East},
+				{geom.Vec2{X: 2, Y: 3}, geom.East},
+				{geom.Vec2{X: 3, Y: 3}, geom.East},
+				{geom.Vec2{X: 4, Y: 3}, geom.East},
+				{geom.Vec2{X: 5, Y: 3}, geom.North},
+				{geom.Vec2{X: 5, Y: 2}, geom.North},
+				{end, geom.North},
+			},
+		},
+		{
+			name: "turning first",
+			path: []Position2{{start, geom.East}, {start, geom.North}, {end, geom.East}},
+			want: []Position2{
+				{start, geom.East},
+				{start, geom.North},
+				{geom.Vec2{X: 1, Y: 2}, geom.North},
+				{geom.Vec2{X: 1, Y: 1}, geom.East},
+				{geom.Vec2{X: 2, Y: 1}, geom.East},
+				{geom.Vec2{X: 3, Y: 1}, geom.East},
+				{geom.Vec2{X: 4, Y: 1}, geom.East},
+				{end, geom.East},
+			},
+		},
+		{
+			name: "turning on the spot",
+			path: []Position2{{start, geom.East}, {start, geom.North}},
+			want: []Position2{{start, geom.East}, {start, geom.North}},
+		},
+	}
+	for _, tt := range tests {
+		if got := g.expand(tt.path, newSolver().rules); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: expand = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+
+	route, ok := findCheapestRouteContracted(board, g, newSolver().rules)
+	if !ok || route.cost != 1006 || !slices.Equal(route.path, tests[0].want) {
+		t.Errorf("contracted route costs %d via %v, want 1006 via %v", route.cost, route.path, tests[0].want)
+	}
+}
+
+// TestLoopCorridor checks a corridor that leaves the junction above S
+// northwards and comes back into it from the east, arriving as a left turn
+// on the spot would. With free moves it is on a cheapest route.
+func TestLoopCorridor(t *testing.T) {
+	maze := `#####
+#...#
+#.#.#
+#...#
+#S###
+#E###
+#####`
+	board, err := parseBoard(maze)
+	if err != nil {
+		t.Fatal(err)
+	}
+	g := contractMaze(board)
+	for _, args := range [][]string{nil, {"-turn", "0"}, {"-step", "0"}, {"-step", "0", "-turn", "0"}} {
+		s := newSolver()
+		if err := s.Configure(args); err != nil {
+			t.Fatal(err)
+		}
+		routes, _ := findAllCheapestRoutes(board, s.rules)
+		_, states, _ := findAllCheapestRoutesContracted(board, g, s.rules)
+		if got, want := tilesOf(states), tilesOf(routes.Nodes()); !maps.Equal(got, want) {
+			t.Errorf("%q: contracted routes cover %d tiles, want %d", args, len(got), len(want))
+		}
+	}
+
+	junction := geom.Vec2{X: 1, Y: 3}
+	path := []Position2{{junction, geom.North}, {junction, geom.West}}
+	free := Rules{}
+	if got := g.expand(path, free); len(got) != 9 {
+		t.Errorf("with free moves, expand = %v, want the loop's 7 tiles between the turn", got)
+	}
+	if got := g.expand(path, newSolver().rules); !slices.Equal(got, path) {
+		t.Errorf("with costly turns, expand = %v, want %v", got, path)
+	}
+}
+
+func TestAnalyzeMaze(t *testing.T) {
+	// S is a junction joined to E, to a second junction at (5,3) by two
+	// corridors, which make a loop, and through that to a dead end at (7,1)
+	maze := `#########
+#.....#.#
+#.###.#.#
+#S......#
+#.#######
+#E#######
+#########`
+	board, err := parseBoard(maze)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := analyzeMaze(board, contractMaze(board))
+	want := Analysis{
+		OpenTiles:    18,
+		Nodes:        4,
+		Junctions:    2,
+		DeadEnds:     1,
+		Corridors:    4,
+		Loops:        1,
+		Branching:    3,
+		MinCorridor:  2,
+		MaxCorridor:  8,
+		MeanCorridor: 4.5,
+	}
+	if got != want {
+		t.Errorf("analyzeMaze = %+v, want %+v", got, want)
+	}
+}
@@ -0,0 +1,193 @@
+package cpu
+
+import (
+	"fmt"
+	"strings"
+
+	io "github.com/faideww/aoc-2024/lib"
+)
+
+// Assemble compiles assembly source into a program. Each line holds one or
+// more statements separated by "/", and anything after ";" or "#" is a
+// comment. A statement is an instruction such as "adv 3", "out b" or
+// "jnz loop", optionally preceded by a "label:", or a register directive such
+// as ".a 2024" setting an initial value.
+//
+// Combo operands are written 0-3 or A/B/C (case-insensitive); jnz takes an
+// address from 0 to 7 or a label, and bxc's operand may be left out.
+func Assemble(source string) (*CPU, error) {
+	type fixup struct {
+		label io.Field
+		index int
+	}
+
+	program := []int{}
+	regs := Registers{}
+	labels := map[string]int{}
+	fixups := []fixup{}
+
+	for _, line := range io.Lines(source) {
+		code := line.Text
+		if i := strings.IndexAny(code, ";#"); i >= 0 {
+			code = code[:i]
+		}
+
+		for _, stmt := range line.Field(0, len(code)).Split("/") {
+			tokens := stmt.Fields()
+			if len(tokens) > 0 && strings.HasSuffix(tokens[0].Text, ":") {
+				name := strings.TrimSuffix(tokens[0].Text, ":")
+				if err := checkLabel(tokens[0], name, labels); err != nil {
+					return nil, err
+				}
+				labels[strings.ToLower(name)] = len(program)
+				tokens = tokens[1:]
+			}
+			if len(tokens) == 0 {
+				continue
+			}
+
+			name, args := strings.ToLower(tokens[0].Text), tokens[1:]
+			if strings.HasPrefix(name, ".") {
+				if err := assembleDirective(tokens[0], args, &regs); err != nil {
+					return nil, err
+				}
+				continue
+			}
+
+			op, ok := parseMnemonic(name)
+			if !ok {
+				return nil, tokens[0].Errorf("unknown instruction %q", tokens[0].Text)
+			}
+
+			operand := 0
+			switch {
+			case op == Bxc && len(args) == 0:
+			case len(args) != 1:
+				return nil, tokens[0].Errorf("%s takes one operand, got %d", op, len(args))
+			case op.Combo():
+				var err error
+				if operand, err = parseCombo(args[0]); err != nil {
+					return nil, err
+				}
+			case op == Jnz && !isNumber(args[0].Text):
+				fixups = append(fixups, fixup{args[0], len(program) + 1})
+			default:
+				var err error
+				if operand, err = parseLiteral(args[0]); err != nil {
+					return nil, err
+				}
+			}
+			program = append(program, int(op), operand)
+		}
+	}
+
+	if len(program) == 0 {
+		return nil, fmt.Errorf("no instructions")
+	}
+
+	for _, f := range fixups {
+		addr, ok := labels[strings.ToLower(f.label.Text)]
+		if !ok {
+			return nil, f.label.Errorf("undefined label %q", f.label.Text)
+		}
+		if addr > 7 {
+			return nil, f.label.Errorf("label %q is at address %d, but jnz can only reach 0-7", f.label.Text, addr)
+		}
+		program[f.index] = addr
+	}
+
+	return New(program, regs), nil
+}
+
+func checkLabel(tok io.Field, name string, labels map[string]int) error {
+	if name == "" || isNumber(name) || strings.ContainsAny(name, ".:") {
+		return tok.Errorf("invalid label %q", tok.Text)
+	}
+	if _, ok := parseRegister(name); ok {
+		return tok.Errorf("label %q clashes with a register name", name)
+	}
+	if _, ok := labels[strings.ToLower(name)]; ok {
+		return tok.Errorf("label %q defined twice", name)
+	}
+	return nil
+}
+
+func assembleDirective(tok io.Field, args []io.Field, regs *Registers) error {
+	reg, ok := parseRegister(strings.TrimPrefix(tok.Text, "."))
+	if !ok {
+		return tok.Errorf("unknown directive %q, expected .a, .b or .c", tok.Text)
+	}
+	if len(args) != 1 {
+		return tok.Errorf("%s takes one value, got %d", tok.Text, len(args))
+	}
+	value, err := args[0].Int()
+	if err != nil {
+		return err
+	}
+	switch reg {
+	case 4:
+		regs.A = value
+	case 5:
+		regs.B = value
+	case 6:
+		regs.C = value
+	}
+	return nil
+}
+
+func parseMnemonic(name string) (Opcode, bool) {
+	for op, m := range mnemonics {
+		if m == name {
+			return Opcode(op), true
+		}
+	}
+	return 0, false
+}
+
+// parseRegister returns the combo operand that reads the named register.
+func parseRegister(name string) (int, bool) {
+	switch strings.ToUpper(name) {
+	case "A":
+		return 4, true
+	case "B":
+		return 5, true
+	case "C":
+		return 6, true
+	}
+	return 0, false
+}
+
+func parseCombo(f io.Field) (int, error) {
+	if reg, ok := parseRegister(f.Text); ok {
+		return reg, nil
+	}
+	value, err := f.Int()
+	if err != nil {
+		return 0, f.Errorf("expected a combo operand (0-3, A, B or C), got %q", f.Text)
+	}
+	switch {
+	case value >= 0 && value <= 3:
+		return value, nil
+	case value >= 4 && value <= 6:
+		return 0, f.Errorf("write combo operand %d as %s", value, comboName(value))
+	case value == 7:
+		return 0, f.Errorf("combo operand 7 is reserved")
+	}
+	return 0, f.Errorf("combo operand %d out of range", value)
+}
+
+func parseLiteral(f io.Field) (int, error) {
+	value, err := f.Int()
+	if err != nil {
+		return 0, err
+	}
+	if value < 0 || value > 7 {
+		return 0, f.Errorf("literal operand %d is not a 3-bit value", value)
+	}
+	return value, nil
+}
+
+func isNumber(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	return s != "" && strings.Trim(s, "0123456789") == ""
+}
@@ -0,0 +1,77 @@
+package cpu
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+// listing writes a program as assembly, one instruction per line.
+func listing(program []int) string {
+	lines := []string{}
+	for _, in := range Decode(program) {
+		lines = append(lines, in.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+func TestAssembleRoundTrip(t *testing.T) {
+	sources := []string{
+		"adv 3\nout A\njnz 0",
+		"bst A\nbxl 1\ncdv B\nbxl 5\nbxc\nout B\nadv 3\njnz 0",
+		"adv 0\nbdv 1\ncdv 2\nbst 3\nout C\nbxl 7\njnz 6",
+		listing(loadInput(t).Program),
+	}
+	for _, source := range sources {
+		c, err := Assemble(source)
+		if err != nil {
+			t.Errorf("Assemble(%q): %v", source, err)
+			continue
+		}
+		if got := listing(c.Program); got != source {
+			t.Errorf("Assemble(%q) disassembles to %q", source, got)
+		}
+	}
+}
+
+func TestAssembleSyntax(t *testing.T) {
+	source := `.a 2024  ; the sample's register A
+loop: adv 3 / out a   # two statements
+      JNZ loop`
+	c, err := Assemble(source)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{0, 3, 5, 4, 3, 0}; !slices.Equal(c.Program, want) {
+		t.Errorf("program = %v, want %v", c.Program, want)
+	}
+	if c.A != 2024 {
+		t.Errorf("A = %d, want 2024", c.A)
+	}
+}
+
+func TestAssembleErrors(t *testing.T) {
+	tests := []struct {
+		name, source, want string
+	}{
+		{"bad mnemonic", "add 3", `unknown instruction "add"`},
+		{"combo out of range", "adv 9", "combo operand 9 out of range"},
+		{"combo operand 7", "out 7", "combo operand 7 is reserved"},
+		{"register as number", "out 4", "write combo operand 4 as A"},
+		{"literal out of range", "bxl 8", "not a 3-bit value"},
+		{"missing operand", "adv", "adv takes one operand, got 0"},
+		{"undefined label", "jnz nowhere", `undefined label "nowhere"`},
+		{"label out of reach", "start: adv 1\nadv 1\nadv 1\nadv 1\nadv 1\nend: jnz end", "jnz can only reach 0-7"},
+		{"duplicate label", "x: adv 1\nx: adv 1", `label "x" defined twice`},
+		{"unknown directive", ".d 1", `unknown directive ".d"`},
+		{"empty", "; nothing here", "no instructions"},
+	}
+	for _, tt := range tests {
+		_, err := Assemble(tt.source)
+		if err == nil {
+			t.Errorf("%s: Assemble(%q) succeeded, want an error", tt.name, tt.source)
+		} else if !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%s: Assemble(%q) = %q, want it to mention %q", tt.name, tt.source, err, tt.want)
+		}
+	}
+}
@@ -0,0 +1,184 @@
+package cpu
+
+import "fmt"
+
+// Compiled is a program translated ahead of time into one Go closure per
+// address, with opcodes and literal operands already decoded. It runs the
+// same programs as CPU, but much faster, which matters when brute-forcing
+// thousands of starting values. Like CPU, it reuses its state between runs
+// and is not safe for concurrent use.
+type Compiled struct {
+	ops []compiledOp
+	m   machine
+}
+
+// machine is the state a compiled program runs against.
+type machine struct {
+	Registers
+	output []int
+	// expected, if set, makes out stop the program as soon as a value
+	// differs from it (or it runs out).
+	expected []int
+	mismatch bool
+	// limit, if non-zero, stops the program after that many outputs.
+	limit int
+	err   error
+}
+
+// emit records an output value and reports whether the program should
+// continue.
+func (m *machine) emit(v int) bool {
+	if m.expected != nil {
+		if len(m.output) >= len(m.expected) || m.expected[len(m.output)] != v {
+			m.mismatch = true
+			return false
+		}
+	}
+	m.output = append(m.output, v)
+	return m.limit == 0 || len(m.output) < m.limit
+}
+
+// compiledOp executes one instruction and returns the next pc, or -1 to stop.
+type compiledOp func(m *machine) int
+
+// Compile decodes the program once. Every even and odd address gets an
+// instruction, so jnz may jump anywhere an interpreted program could.
+func Compile(program []int) (*Compiled, error) {
+	p := &Compiled{ops: make([]compiledOp, max(len(program)-1, 0))}
+	for pc := range p.ops {
+		op, err := compileOp(pc, Opcode(program[pc]), program[pc+1])
+		if err != nil {
+			return nil, err
+		}
+		p.ops[pc] = op
+	}
+	return p, nil
+}
+
+func compileOp(pc int, op Opcode, operand int) (compiledOp, error) {
+	next := pc + 2
+	var combo func(m *machine) int
+	if op.Combo() {
+		switch operand {
+		case 4:
+			combo = func(m *machine) int { return m.A }
+		case 5:
+			combo = func(m *machine) int { return m.B }
+		case 6:
+			combo = func(m *machine) int { return m.C }
+		case 7:
+			// only an error if the instruction is ever reached
+			return func(m *machine) int {
+				m.err = fmt.Errorf("pc %d: combo operand 7 is reserved", pc)
+				return -1
+			}, nil
+		default:
+			literal := operand
+			combo = func(*machine) int { return literal }
+		}
+	}
+
+	// dv divides A by 2^combo, as adv, bdv and cdv do.
+	dv := func(m *machine) (int, bool) {
+		n := combo(m)
+		if n < 0 {
+			m.err = fmt.Errorf("pc %d: %s cannot divide by 2^%d", pc, op, n)
+			return 0, false
+		}
+		return shift(m.A, n), true
+	}
+
+	switch op {
+	case Adv:
+		if operand >= 0 && operand <= 3 {
+			// the common case: a constant shift
+			return func(m *machine) int { m.A = shift(m.A, operand); return next }, nil
+		}
+		return func(m *machine) int {
+			a, ok := dv(m)
+			if !ok {
+				return -1
+			}
+			m.A = a
+			return next
+		}, nil
+	case Bxl:
+		return func(m *machine) int { m.B ^= operand; return next }, nil
+	case Bst:
+		return func(m *machine) int { m.B = combo(m) & 7; return next }, nil
+	case Jnz:
+		return func(m *machine) int {
+			if m.A != 0 {
+				return operand
+			}
+			return next
+		}, nil
+	case Bxc:
+		return func(m *machine) int { m.B ^= m.C; return next }, nil
+	case Out:
+		return func(m *machine) int {
+			if !m.emit(combo(m) & 7) {
+				return -1
+			}
+			return next
+		}, nil
+	case Bdv:
+		return func(m *machine) int {
+			b, ok := dv(m)
+			if !ok {
+				return -1
+			}
+			m.B = b
+			return next
+		}, nil
+	case Cdv:
+		return func(m *machine) int {
+			c, ok := dv(m)
+			if !ok {
+				return -1
+			}
+			m.C = c
+			return next
+		}, nil
+	}
+	return nil, fmt.Errorf("pc %d: invalid opcode %d", pc, op)
+}
+
+// exec runs the program from address 0 until it halts, an output stops it,
+// or it has run maxSteps instructions (if maxSteps > 0).
+func (p *Compiled) exec(regs Registers, maxSteps int, expected []int, limit int) (*machine, error) {
+	m := &p.m
+	*m = machine{Registers: regs, output: m.output[:0], expected: expected, limit: limit}
+	for pc, steps := 0, 0; pc >= 0 && pc < len(p.ops); steps++ {
+		if maxSteps > 0 && steps >= maxSteps {
+			return m, ErrStepLimit
+		}
+		pc = p.ops[pc](m)
+	}
+	return m, m.err
+}
+
+// Run executes the program with the given registers and returns its output.
+// The output slice is reused by the next run, so copy it to keep it.
+func (p *Compiled) Run(regs Registers, maxSteps int) ([]int, error) {
+	m, err := p.exec(regs, maxSteps, nil, 0)
+	return m.output, err
+}
+
+// Matches reports whether the program outputs exactly expected. It compares
+// values as they are produced and stops at the first mismatch, so most
+// wrong guesses cost only a few instructions.
+func (p *Compiled) Matches(regs Registers, expected []int, maxSteps int) (bool, error) {
+	m, err := p.exec(regs, maxSteps, expected, 0)
+	return !m.mismatch && len(m.output) == len(expected), err
+}
+
+// First runs the program until its first output and returns it, or false if
+// the program halts without output.
+func (p *Compiled) First(regs Registers, maxSteps int) (int, bool, error) {
+	m, err := p.exec(regs, maxSteps, nil, 1)
+	if len(m.output) == 0 {
+		return 0, false, err
+	}
+	return m.output[0], true, err
+}
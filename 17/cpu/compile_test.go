@@ -0,0 +1,92 @@
+package cpu
+
+import (
+	"slices"
+	"testing"
+
+	io "github.com/faideww/aoc-2024/lib"
+)
+
+func loadInput(tb testing.TB) *CPU {
+	tb.Helper()
+	input, err := io.ReadInputFile("../in.txt")
+	if err != nil {
+		tb.Fatal(err)
+	}
+	c, err := Parse(input)
+	if err != nil {
+		tb.Fatal(err)
+	}
+	return c
+}
+
+func TestCompiledMatchesInterpreter(t *testing.T) {
+	c := loadInput(t)
+	compiled, err := Compile(c.Program)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for a := 0; a < 1<<12; a += 7 {
+		c.Reset(Registers{A: a})
+		want, err := c.Run()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := compiled.Run(Registers{A: a}, DefaultMaxSteps)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !slices.Equal(got, want) {
+			t.Fatalf("A=%d: compiled output %v, interpreter %v", a, got, want)
+		}
+		if ok, _ := compiled.Matches(Registers{A: a}, want, DefaultMaxSteps); !ok {
+			t.Fatalf("A=%d: Matches rejected the interpreter's output", a)
+		}
+	}
+}
+
+// BenchmarkBruteForce checks a range of A values against the program, as a
+// brute-force quine search would.
+func BenchmarkBruteForce(b *testing.B) {
+	c := loadInput(b)
+	compiled, err := Compile(c.Program)
+	if err != nil {
+		b.Fatal(err)
+	}
+	const start, candidates = 1 << 45, 1000
+
+	b.Run("interpreter", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for a := start; a < start+candidates; a++ {
+				c.Reset(Registers{A: a})
+				output, _ := c.Run()
+				if slices.Equal(output, c.Program) {
+					b.Fatal("unexpected quine")
+				}
+			}
+		}
+	})
+	b.Run("compiled", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for a := start; a < start+candidates; a++ {
+				output, _ := compiled.Run(Registers{A: a}, DefaultMaxSteps)
+				if slices.Equal(output, c.Program) {
+					b.Fatal("unexpected quine")
+				}
+			}
+		}
+	})
+	b.Run("compiled-match", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			for a := start; a < start+candidates; a++ {
+				if ok, _ := compiled.Matches(Registers{A: a}, c.Program, DefaultMaxSteps); ok {
+					b.Fatal("unexpected quine")
+				}
+			}
+		}
+	})
+}
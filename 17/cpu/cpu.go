@@ -0,0 +1,187 @@
+// Package cpu implements day 17's 3-bit computer.
+package cpu
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// Opcode is one of the machine's eight instructions.
+type Opcode int
+
+const (
+	Adv Opcode = iota // A = A >> combo
+	Bxl               // B = B ^ literal
+	Bst               // B = combo & 7
+	Jnz               // if A != 0, jump to literal
+	Bxc               // B = B ^ C (operand ignored)
+	Out               // output combo & 7
+	Bdv               // B = A >> combo
+	Cdv               // C = A >> combo
+)
+
+var mnemonics = [8]string{"adv", "bxl", "bst", "jnz", "bxc", "out", "bdv", "cdv"}
+
+func (op Opcode) String() string { return mnemonics[op] }
+
+// Combo reports whether op's operand is a combo operand (0-3 literal, 4-6 a
+// register). The others take a literal, apart from bxc which ignores it.
+func (op Opcode) Combo() bool {
+	switch op {
+	case Adv, Bst, Out, Bdv, Cdv:
+		return true
+	}
+	return false
+}
+
+// DefaultMaxSteps is the step limit given to parsed programs. Day 17's
+// programs finish in a few hundred steps, so hitting it means a loop.
+const DefaultMaxSteps = 1_000_000
+
+// ErrStepLimit is returned when a program runs for more than MaxSteps
+// instructions.
+var ErrStepLimit = errors.New("step limit reached, the program may never halt")
+
+type Registers struct {
+	A, B, C int
+}
+
+func (r Registers) String() string {
+	return fmt.Sprintf("A=%d (0o%o) B=%d C=%d", r.A, r.A, r.B, r.C)
+}
+
+// CPU is the machine state: registers, the loaded program and everything it
+// has output so far.
+type CPU struct {
+	Registers
+	Program []int
+	PC      int
+	Output  []int
+
+	// Steps counts executed instructions. Once it reaches MaxSteps (if
+	// non-zero), Step refuses to go any further.
+	Steps    int
+	MaxSteps int
+
+	// Trace, if set, receives a line per executed instruction showing the
+	// registers afterwards.
+	Trace io.Writer
+}
+
+// New returns a CPU with the given program loaded and the default step limit.
+func New(program []int, regs Registers) *CPU {
+	return &CPU{Registers: regs, Program: program, MaxSteps: DefaultMaxSteps}
+}
+
+// Reset restarts the program from the beginning with new register values.
+// The output buffer is reused, so copy any output you want to keep first.
+func (c *CPU) Reset(regs Registers) {
+	c.Registers = regs
+	c.PC = 0
+	c.Output = c.Output[:0]
+	c.Steps = 0
+}
+
+// Halted reports whether the program counter has left the program.
+func (c *CPU) Halted() bool {
+	return c.PC < 0 || c.PC+1 >= len(c.Program)
+}
+
+// Step executes the instruction at PC.
+func (c *CPU) Step() error {
+	if c.Halted() {
+		return fmt.Errorf("cpu is halted")
+	}
+	if c.MaxSteps > 0 && c.Steps >= c.MaxSteps {
+		return ErrStepLimit
+	}
+
+	pc := c.PC
+	op, operand := Opcode(c.Program[pc]), c.Program[pc+1]
+	combo := operand
+	if op.Combo() {
+		var err error
+		if combo, err = c.combo(operand); err != nil {
+			return fmt.Errorf("pc %d: %w", pc, err)
+		}
+		if combo < 0 && (op == Adv || op == Bdv || op == Cdv) {
+			return fmt.Errorf("pc %d: %s cannot divide by 2^%d", pc, op, combo)
+		}
+	}
+
+	c.PC += 2
+	switch op {
+	case Adv:
+		c.A = shift(c.A, combo)
+	case Bxl:
+		c.B ^= operand
+	case Bst:
+		c.B = combo & 7
+	case Jnz:
+		if c.A != 0 {
+			c.PC = operand
+		}
+	case Bxc:
+		c.B ^= c.C
+	case Out:
+		c.Output = append(c.Output, combo&7)
+	case Bdv:
+		c.B = shift(c.A, combo)
+	case Cdv:
+		c.C = shift(c.A, combo)
+	}
+	c.Steps++
+
+	if c.Trace != nil {
+		fmt.Fprintf(c.Trace, "%3d  %-8s %s", pc, Instruction{op, operand}, c.Registers)
+		if op == Out {
+			fmt.Fprintf(c.Trace, "  out %d", combo&7)
+		}
+		fmt.Fprintln(c.Trace)
+	}
+	return nil
+}
+
+// Run executes instructions until the program halts and returns its output.
+func (c *CPU) Run() ([]int, error) {
+	for !c.Halted() {
+		if err := c.Step(); err != nil {
+			return c.Output, err
+		}
+	}
+	return c.Output, nil
+}
+
+// FormatOutput joins output values with commas, as the puzzle answer expects.
+func FormatOutput(output []int) string {
+	strs := make([]string, len(output))
+	for i, o := range output {
+		strs[i] = strconv.Itoa(o)
+	}
+	return strings.Join(strs, ",")
+}
+
+func (c *CPU) combo(operand int) (int, error) {
+	switch operand {
+	case 4:
+		return c.A, nil
+	case 5:
+		return c.B, nil
+	case 6:
+		return c.C, nil
+	case 7:
+		return 0, fmt.Errorf("combo operand 7 is reserved")
+	}
+	return operand, nil
+}
+
+// shift divides a by 2^n (n >= 0), the way the dv instructions do.
+func shift(a, n int) int {
+	if n >= 63 {
+		return 0
+	}
+	return a / (1 << n)
+}
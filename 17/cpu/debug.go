@@ -0,0 +1,180 @@
+package cpu
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const debugHelp = `commands:
+  s, step [n]        execute n instructions (default 1), tracing each one
+  c, continue        run until a breakpoint or the program halts
+  b, break [pc]      toggle a breakpoint at pc, or list breakpoints
+  l, list            disassemble the program, marking pc and breakpoints
+  r, regs            show the registers, pc and step count
+  o, out             show the output so far
+  set <a|b|c> <n>    change a register
+  reset [a]          restart the program, optionally with a new A
+  q, quit            leave the debugger
+`
+
+// Debugger drives a CPU from a simple line-based command prompt.
+type Debugger struct {
+	cpu         *CPU
+	initial     Registers
+	breakpoints map[int]bool
+	out         io.Writer
+}
+
+// NewDebugger wraps c. Reset restores the registers c has now.
+func NewDebugger(c *CPU) *Debugger {
+	return &Debugger{cpu: c, initial: c.Registers, breakpoints: make(map[int]bool)}
+}
+
+// Run reads commands from in until it is exhausted or the user quits.
+func (d *Debugger) Run(in io.Reader, out io.Writer) error {
+	d.out = out
+	scanner := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "(cpu) ")
+		if !scanner.Scan() {
+			fmt.Fprintln(out)
+			return scanner.Err()
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if fields[0] == "q" || fields[0] == "quit" {
+			return nil
+		}
+		if err := d.exec(fields[0], fields[1:]); err != nil {
+			fmt.Fprintf(out, "error: %v\n", err)
+		}
+	}
+}
+
+func (d *Debugger) exec(cmd string, args []string) error {
+	c := d.cpu
+	switch cmd {
+	case "h", "help":
+		fmt.Fprint(d.out, debugHelp)
+	case "s", "step":
+		n := 1
+		if len(args) > 0 {
+			var err error
+			if n, err = strconv.Atoi(args[0]); err != nil {
+				return err
+			}
+		}
+		c.Trace = d.out
+		defer func() { c.Trace = nil }()
+		for i := 0; i < n && !c.Halted(); i++ {
+			if err := c.Step(); err != nil {
+				return err
+			}
+		}
+		d.reportHalt()
+	case "c", "continue":
+		for !c.Halted() {
+			if err := c.Step(); err != nil {
+				return err
+			}
+			if d.breakpoints[c.PC] {
+				fmt.Fprintf(d.out, "breakpoint at %d: %s\n", c.PC, d.current())
+				return nil
+			}
+		}
+		d.reportHalt()
+	case "b", "break":
+		if len(args) == 0 {
+			pcs := []int{}
+			for pc := range d.breakpoints {
+				pcs = append(pcs, pc)
+			}
+			slices.Sort(pcs)
+			fmt.Fprintf(d.out, "breakpoints: %v\n", pcs)
+			return nil
+		}
+		pc, err := strconv.Atoi(args[0])
+		if err != nil {
+			return err
+		}
+		if pc < 0 || pc >= len(c.Program) || pc%2 != 0 {
+			return fmt.Errorf("%d is not an instruction address", pc)
+		}
+		if d.breakpoints[pc] {
+			delete(d.breakpoints, pc)
+			fmt.Fprintf(d.out, "cleared breakpoint at %d\n", pc)
+		} else {
+			d.breakpoints[pc] = true
+			fmt.Fprintf(d.out, "set breakpoint at %d\n", pc)
+		}
+	case "l", "list":
+		for i, in := range Decode(c.Program) {
+			marker := "  "
+			if 2*i == c.PC {
+				marker = "=>"
+			}
+			bp := " "
+			if d.breakpoints[2*i] {
+				bp = "*"
+			}
+			fmt.Fprintf(d.out, "%s%s%3d  %-8s ; %s\n", marker, bp, 2*i, in, in.Pseudo())
+		}
+	case "r", "regs":
+		fmt.Fprintf(d.out, "pc=%d steps=%d %s\n", c.PC, c.Steps, c.Registers)
+	case "o", "out":
+		fmt.Fprintln(d.out, FormatOutput(c.Output))
+	case "set":
+		if len(args) != 2 {
+			return errors.New("usage: set <a|b|c> <value>")
+		}
+		value, err := strconv.Atoi(args[1])
+		if err != nil {
+			return err
+		}
+		switch strings.ToUpper(args[0]) {
+		case "A":
+			c.A = value
+		case "B":
+			c.B = value
+		case "C":
+			c.C = value
+		default:
+			return fmt.Errorf("unknown register %q", args[0])
+		}
+	case "reset":
+		regs := d.initial
+		if len(args) > 0 {
+			a, err := strconv.Atoi(args[0])
+			if err != nil {
+				return err
+			}
+			regs.A = a
+		}
+		c.Reset(regs)
+	default:
+		return fmt.Errorf("unknown command %q (try help)", cmd)
+	}
+	return nil
+}
+
+// current describes the instruction at PC.
+func (d *Debugger) current() string {
+	c := d.cpu
+	if c.Halted() {
+		return "halted"
+	}
+	return Instruction{Opcode(c.Program[c.PC]), c.Program[c.PC+1]}.String()
+}
+
+func (d *Debugger) reportHalt() {
+	if d.cpu.Halted() {
+		fmt.Fprintf(d.out, "halted after %d steps, output: %s\n", d.cpu.Steps, FormatOutput(d.cpu.Output))
+	}
+}
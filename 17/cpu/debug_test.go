@@ -0,0 +1,91 @@
+package cpu
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// debug runs the debugger on the sample program with A=2024, feeding it the
+// given commands, and returns what it printed.
+func debug(t *testing.T, commands ...string) string {
+	t.Helper()
+	c := New([]int{0, 3, 5, 4, 3, 0}, Registers{A: 2024})
+	var out bytes.Buffer
+	if err := NewDebugger(c).Run(strings.NewReader(strings.Join(commands, "\n")), &out); err != nil {
+		t.Fatal(err)
+	}
+	return out.String()
+}
+
+func TestDebuggerStep(t *testing.T) {
+	got := debug(t, "s", "r", "s 2", "r", "s 100", "o")
+	want := "(cpu)   0  adv 3    A=253 (0o375) B=0 C=0\n" +
+		"(cpu) pc=2 steps=1 A=253 (0o375) B=0 C=0\n" +
+		"(cpu)   2  out A    A=253 (0o375) B=0 C=0  out 5\n" +
+		"  4  jnz 0    A=253 (0o375) B=0 C=0\n" +
+		"(cpu) pc=0 steps=3 A=253 (0o375) B=0 C=0\n"
+	if !strings.HasPrefix(got, want) {
+		t.Errorf("stepping printed\n%s\nwant it to start with\n%s", got, want)
+	}
+	if !strings.Contains(got, "halted after 12 steps, output: 5,7,3,0\n(cpu) 5,7,3,0\n") {
+		t.Errorf("stepping to the end printed\n%s\nwant the program to halt with 5,7,3,0", got)
+	}
+}
+
+func TestDebuggerBreakpoints(t *testing.T) {
+	tests := []struct {
+		name     string
+		commands []string
+		want     []string
+	}{
+		{
+			name:     "stops at a breakpoint",
+			commands: []string{"b 4", "c", "r", "l", "o", "c", "r"},
+			want: []string{
+				"set breakpoint at 4",
+				"breakpoint at 4: jnz 0\n(cpu) pc=4 steps=2 A=253",
+				"=>*  4  jnz 0    ; if A != 0 goto 0",
+				"(cpu) 5\n",
+				"breakpoint at 4: jnz 0\n(cpu) pc=4 steps=5 A=31",
+			},
+		},
+		{
+			name:     "cleared breakpoints don't stop",
+			commands: []string{"b 4", "b 2", "b", "b 4", "b", "c"},
+			want: []string{
+				"breakpoints: [2 4]",
+				"cleared breakpoint at 4",
+				"breakpoints: [2]",
+				"breakpoint at 2: out A",
+			},
+		},
+		{
+			name:     "runs to the end without breakpoints",
+			commands: []string{"c"},
+			want:     []string{"halted after 12 steps, output: 5,7,3,0"},
+		},
+		{
+			name:     "reset with a new A",
+			commands: []string{"c", "reset 117440", "c"},
+			want:     []string{"halted after 18 steps, output: 0,3,5,4,3,0"},
+		},
+		{
+			name:     "bad addresses",
+			commands: []string{"b 3", "b 6", "b x"},
+			want: []string{
+				"error: 3 is not an instruction address",
+				"error: 6 is not an instruction address",
+				"error: strconv.Atoi",
+			},
+		},
+	}
+	for _, tt := range tests {
+		got := debug(t, tt.commands...)
+		for _, w := range tt.want {
+			if !strings.Contains(got, w) {
+				t.Errorf("%s: debugger printed\n%s\nwant it to include %q", tt.name, got, w)
+			}
+		}
+	}
+}
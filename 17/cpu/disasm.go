@@ -0,0 +1,82 @@
+package cpu
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Instruction is a decoded opcode/operand pair.
+type Instruction struct {
+	Op      Opcode
+	Operand int
+}
+
+// Decode splits a program into instructions. A trailing opcode without an
+// operand is dropped, since the machine halts before reaching it.
+func Decode(program []int) []Instruction {
+	instructions := make([]Instruction, len(program)/2)
+	for i := range instructions {
+		instructions[i] = Instruction{Opcode(program[2*i]), program[2*i+1]}
+	}
+	return instructions
+}
+
+// String formats the instruction as assembly, eg. "adv 3" or "out B". Combo
+// operands that read a register are shown as the register's name.
+func (in Instruction) String() string {
+	switch {
+	case in.Op == Bxc && in.Operand == 0:
+		return in.Op.String()
+	case in.Op.Combo():
+		return fmt.Sprintf("%s %s", in.Op, comboName(in.Operand))
+	}
+	return fmt.Sprintf("%s %d", in.Op, in.Operand)
+}
+
+// Pseudo describes what the instruction does in C-like pseudocode.
+func (in Instruction) Pseudo() string {
+	x := comboName(in.Operand)
+	switch in.Op {
+	case Adv:
+		return "A = A >> " + x
+	case Bxl:
+		return fmt.Sprintf("B = B ^ %d", in.Operand)
+	case Bst:
+		return "B = " + x + " & 7"
+	case Jnz:
+		return fmt.Sprintf("if A != 0 goto %d", in.Operand)
+	case Bxc:
+		return "B = B ^ C"
+	case Out:
+		return "out " + x + " & 7"
+	case Bdv:
+		return "B = A >> " + x
+	case Cdv:
+		return "C = A >> " + x
+	}
+	return "?"
+}
+
+func comboName(operand int) string {
+	switch operand {
+	case 4:
+		return "A"
+	case 5:
+		return "B"
+	case 6:
+		return "C"
+	case 7:
+		return "<invalid 7>"
+	}
+	return fmt.Sprint(operand)
+}
+
+// Disassemble returns a listing of the program, one instruction per line with
+// its address and a pseudocode comment.
+func Disassemble(program []int) string {
+	var sb strings.Builder
+	for i, in := range Decode(program) {
+		fmt.Fprintf(&sb, "%3d  %-8s ; %s\n", 2*i, in, in.Pseudo())
+	}
+	return sb.String()
+}
@@ -0,0 +1,32 @@
+package cpu
+
+import "testing"
+
+func TestDisassemble(t *testing.T) {
+	tests := []struct {
+		program []int
+		want    string
+	}{
+		{
+			[]int{0, 3, 5, 4, 3, 0},
+			"  0  adv 3    ; A = A >> 3\n" +
+				"  2  out A    ; out A & 7\n" +
+				"  4  jnz 0    ; if A != 0 goto 0\n",
+		},
+		{
+			[]int{1, 7, 2, 6, 4, 0, 6, 5, 7, 2},
+			"  0  bxl 7    ; B = B ^ 7\n" +
+				"  2  bst C    ; B = C & 7\n" +
+				"  4  bxc      ; B = B ^ C\n" +
+				"  6  bdv B    ; B = A >> B\n" +
+				"  8  cdv 2    ; C = A >> 2\n",
+		},
+		// a trailing opcode without an operand is dropped
+		{[]int{5, 7, 3}, "  0  out <invalid 7> ; out <invalid 7> & 7\n"},
+	}
+	for _, tt := range tests {
+		if got := Disassemble(tt.program); got != tt.want {
+			t.Errorf("Disassemble(%v) =\n%s\nwant\n%s", tt.program, got, tt.want)
+		}
+	}
+}
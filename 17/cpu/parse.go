@@ -0,0 +1,62 @@
+package cpu
+
+import (
+	"fmt"
+	"regexp"
+
+	io "github.com/faideww/aoc-2024/lib"
+)
+
+var programRegex = regexp.MustCompile("^Program: (.+)$")
+
+// Parse reads the puzzle's register block and program line.
+func Parse(input string) (*CPU, error) {
+	sections := io.Sections(input)
+	if len(sections) != 2 || len(sections[0]) != 3 || len(sections[1]) != 1 {
+		return nil, fmt.Errorf("expected 3 register lines, a blank line and a program line")
+	}
+
+	registers := make([]int, 3)
+	for i, name := range []string{"A", "B", "C"} {
+		registerRegex := regexp.MustCompile("^Register " + name + ": (.+)$")
+		match, err := sections[0][i].Match(registerRegex)
+		if err != nil {
+			return nil, err
+		}
+		registers[i], err = match[0].Int()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	progMatch, err := sections[1][0].Match(programRegex)
+	if err != nil {
+		return nil, err
+	}
+
+	programFields := progMatch[0].Split(",")
+
+	program := make([]int, len(programFields))
+	for i, field := range programFields {
+		code, err := field.Int()
+		if err != nil {
+			return nil, err
+		}
+		if code < 0 || code > 7 {
+			return nil, field.Errorf("expected a 3-bit value, got %d", code)
+		}
+		program[i] = code
+	}
+	if len(program)%2 != 0 {
+		return nil, sections[1][0].Errorf(0, "program has an opcode without an operand")
+	}
+
+	return New(program, Registers{registers[0], registers[1], registers[2]}), nil
+}
+
+// Format writes registers and a program in the puzzle's input format, which
+// Parse reads back.
+func Format(regs Registers, program []int) string {
+	return fmt.Sprintf("Register A: %d\nRegister B: %d\nRegister C: %d\n\nProgram: %s\n",
+		regs.A, regs.B, regs.C, FormatOutput(program))
+}
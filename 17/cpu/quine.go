@@ -0,0 +1,152 @@
+package cpu
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrNoQuine is returned by FindQuines when no value of A makes the program
+// output itself.
+var ErrNoQuine = errors.New("no value of A makes the program output itself")
+
+// Loop describes a program of the shape day 17 uses: a single loop that
+// outputs one value and shifts A right by a fixed amount each time round,
+// ending in "jnz 0".
+type Loop struct {
+	// Shift is how many bits A loses per iteration.
+	Shift int
+	// Out is the instruction that produces each iteration's output.
+	Out Instruction
+}
+
+// AnalyzeLoop checks that program has the shape FindQuines relies on and
+// works out its shift amount. B and C must be written before they are read in
+// the loop body, so that each iteration depends on A alone.
+func AnalyzeLoop(program []int) (Loop, error) {
+	instructions := Decode(program)
+	if len(instructions) == 0 {
+		return Loop{}, fmt.Errorf("empty program")
+	}
+	if last := instructions[len(instructions)-1]; last != (Instruction{Jnz, 0}) {
+		return Loop{}, fmt.Errorf("program must end in jnz 0, got %s", last)
+	}
+
+	loop := Loop{}
+	outs, advs := 0, 0
+	written := map[int]bool{} // combo operands 5 (B) and 6 (C) once set
+	for i, in := range instructions[:len(instructions)-1] {
+		pc := 2 * i
+		var reads []int
+		switch in.Op {
+		case Jnz:
+			return Loop{}, fmt.Errorf("pc %d: only the final instruction may jump", pc)
+		case Bxl:
+			reads = []int{5}
+		case Bxc:
+			reads = []int{5, 6}
+		}
+		if in.Op.Combo() {
+			if in.Operand == 7 {
+				return Loop{}, fmt.Errorf("pc %d: combo operand 7 is reserved", pc)
+			}
+			reads = append(reads, in.Operand)
+		}
+		for _, r := range reads {
+			if (r == 5 || r == 6) && !written[r] {
+				return Loop{}, fmt.Errorf("pc %d: %s reads %s before the loop sets it, so iterations are not independent", pc, in, comboName(r))
+			}
+		}
+
+		switch in.Op {
+		case Adv:
+			if in.Operand < 1 || in.Operand > 3 {
+				return Loop{}, fmt.Errorf("pc %d: A must be shifted by a constant 1-3, got %s", pc, in)
+			}
+			loop.Shift = in.Operand
+			advs++
+		case Out:
+			loop.Out = in
+			outs++
+		case Bxl, Bst, Bxc, Bdv:
+			written[5] = true
+		case Cdv:
+			written[6] = true
+		}
+	}
+
+	if advs != 1 {
+		return Loop{}, fmt.Errorf("expected exactly one adv per loop, found %d", advs)
+	}
+	if outs != 1 {
+		return Loop{}, fmt.Errorf("expected exactly one out per loop, found %d", outs)
+	}
+	return loop, nil
+}
+
+// FindQuines returns values of A, smallest first, for which the program
+// outputs a copy of itself. Unless all is set it stops at the smallest. Each
+// run of the program is cut off after maxSteps instructions (0 for no
+// limit).
+//
+// Iteration i of the loop sees A >> (i * Shift), so the last output depends
+// only on A's top Shift bits, the one before on the top 2*Shift bits, and so
+// on. The search fixes A a digit at a time from the top, trying each digit
+// against the matching output and backtracking when none fit.
+func FindQuines(program []int, all bool, maxSteps int) ([]int, error) {
+	loop, err := AnalyzeLoop(program)
+	if err != nil {
+		return nil, err
+	}
+	if len(program)*loop.Shift >= 63 {
+		return nil, fmt.Errorf("a %d-value quine needs more than 63 bits of A", len(program))
+	}
+
+	compiled, err := Compile(program)
+	if err != nil {
+		return nil, err
+	}
+	found := []int{}
+
+	var search func(a, i int) error
+	search = func(a, i int) error {
+		if i < 0 {
+			found = append(found, a)
+			return nil
+		}
+		for digit := 0; digit < 1<<loop.Shift; digit++ {
+			candidate := a<<loop.Shift | digit
+			if candidate == 0 {
+				// A must stay non-zero until the last iteration, or the
+				// loop stops early
+				continue
+			}
+			// run a single iteration, stopping at its output
+			out, ok, err := compiled.First(Registers{A: candidate}, maxSteps)
+			if err != nil {
+				return err
+			}
+			if !ok || out != program[i] {
+				continue
+			}
+			if err := search(candidate, i-1); err != nil {
+				return err
+			}
+			if len(found) > 0 && !all {
+				return nil
+			}
+		}
+		return nil
+	}
+	if err := search(0, len(program)-1); err != nil {
+		return nil, err
+	}
+	if len(found) == 0 {
+		return nil, ErrNoQuine
+	}
+
+	// double-check the first answer with a full run
+	if ok, err := compiled.Matches(Registers{A: found[0]}, program, maxSteps); err != nil || !ok {
+		return nil, fmt.Errorf("A=%d does not reproduce the program (err %v)", found[0], err)
+	}
+	return found, nil
+}
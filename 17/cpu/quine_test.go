@@ -0,0 +1,44 @@
+package cpu
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+func TestFindQuines(t *testing.T) {
+	sample := []int{0, 3, 5, 4, 3, 0}
+
+	quines, err := FindQuines(sample, false, DefaultMaxSteps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(quines, []int{117440}) {
+		t.Errorf("FindQuines = %v, want [117440]", quines)
+	}
+
+	all, err := FindQuines(sample, true, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) == 0 || all[0] != 117440 || !slices.IsSorted(all) {
+		t.Errorf("FindQuines with all = %v, want 117440 first and the rest in order", all)
+	}
+
+	// each iteration takes three instructions, so one isn't enough to reach
+	// its output
+	if _, err := FindQuines(sample, false, 1); !errors.Is(err, ErrStepLimit) {
+		t.Errorf("FindQuines with a step limit of 1 = %v, want ErrStepLimit", err)
+	}
+}
+
+func TestFindQuinesInput(t *testing.T) {
+	c := loadInput(t)
+	quines, err := FindQuines(c.Program, false, DefaultMaxSteps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(quines, []int{202322348616234}) {
+		t.Errorf("FindQuines = %v, want [202322348616234]", quines)
+	}
+}
@@ -0,0 +1,37 @@
+package day17
+
+import (
+	"github.com/faideww/aoc-2024/17/cpu"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+func init() {
+	runner.Register(17, solver{})
+}
+
+type solver struct{}
+
+func (solver) Part1(input string) (runner.Answer, error) {
+	c, err := cpu.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+
+	output, err := c.Run()
+	if err != nil {
+		return nil, err
+	}
+	return runner.Text(cpu.FormatOutput(output)), nil
+}
+
+func (solver) Part2(input string) (runner.Answer, error) {
+	c, err := cpu.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	quines, err := cpu.FindQuines(c.Program, false, c.MaxSteps)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(quines[0]), nil
+}
@@ -0,0 +1,15 @@
+package day17
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "4,6,3,5,6,3,5,2,1,0"}, // not a quine, so there is no part 2 answer
+		{Input: "test2.txt", Part1: "5,7,3,0", Part2: "117440"},
+		{Input: "in.txt", Part1: "4,0,4,7,1,2,7,1,6", Part2: "202322348616234"},
+	})
+}
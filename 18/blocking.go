@@ -0,0 +1,164 @@
+package day18
+
+import (
+	"sort"
+
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/grid"
+)
+
+// blockingFinders find the index of the first byte that cuts the exit off
+// from the start, by name. from is a hint: a number of bytes expected to
+// leave a path open, so the answer is at index from or later. If the hint is
+// wrong and those bytes already block the path, the finders search the
+// bytes before it too, so they all give the same answer whatever from is.
+var blockingFinders = map[string]func(board Board, from int) (int, bool){
+	"linear":    findBlockingLinear,
+	"binary":    findBlockingBinary,
+	"unionfind": findBlockingUnionFind,
+}
+
+// blockedAfter reports whether the first n bytes leave no path, rebuilding
+// the arena to find out.
+func blockedAfter(board Board, n int) bool {
+	board.tiles = grid.New[int](board.tiles.Width, board.tiles.Height)
+	for i := 0; i < n; i++ {
+		simulateByte(&board, i)
+	}
+	_, hasPath := findShortestPath(board)
+	return !hasPath
+}
+
+// findBlockingLinear drops one byte at a time, searching for a path after
+// each, until there isn't one.
+func findBlockingLinear(board Board, from int) (int, bool) {
+	from = min(max(from, 0), len(board.bytes))
+	if blockedAfter(board, from) {
+		from = 0
+	}
+	board.tiles = grid.New[int](board.tiles.Width, board.tiles.Height)
+	for i := 0; i < from; i++ {
+		simulateByte(&board, i)
+	}
+	for i := from; i < len(board.bytes); i++ {
+		simulateByte(&board, i)
+		if _, hasPath := findShortestPath(board); !hasPath {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// findBlockingBinary searches for the smallest number of bytes that leaves
+// no path, rebuilding the arena for each guess. Once the path is blocked it
+// stays blocked, so this needs only a logarithmic number of searches.
+func findBlockingBinary(board Board, from int) (int, bool) {
+	from = min(max(from, 0), len(board.bytes))
+	if blockedAfter(board, from) {
+		from = 0
+	}
+	n := from + sort.Search(len(board.bytes)-from+1, func(i int) bool { return blockedAfter(board, from+i) })
+	if n > len(board.bytes) || n == 0 {
+		return 0, false
+	}
+	return n - 1, true
+}
+
+// findBlockingUnionFind works backwards from the arena with every byte
+// fallen, clearing bytes in reverse order and joining each cleared tile to
+// its open neighbours. The byte whose removal first connects the start to
+// the exit is the one that blocked it. Bytes before from are left fallen
+// until the sweep reaches them, which only happens if the hint was wrong.
+func findBlockingUnionFind(board Board, from int) (int, bool) {
+	from = min(max(from, 0), len(board.bytes))
+	width, height := board.tiles.Width, board.tiles.Height
+	index := func(p geom.Vec2) int { return p.Y*width + p.X }
+	start, goal := geom.Vec2{X: 0, Y: 0}, geom.Vec2{X: width - 1, Y: height - 1}
+
+	// a tile hit more than once only clears when its first byte is removed
+	firstHit := map[geom.Vec2]int{}
+	for i, pos := range board.bytes {
+		if _, ok := firstHit[pos]; !ok && board.tiles.In(pos) {
+			firstHit[pos] = i
+		}
+	}
+
+	open := make([]bool, width*height)
+	sets := newDisjointSet(width * height)
+	clear := func(p geom.Vec2) {
+		open[index(p)] = true
+		for _, d := range geom.Dirs {
+			n := p.Add(d.Vec())
+			if board.tiles.In(n) && open[index(n)] {
+				sets.union(index(p), index(n))
+			}
+		}
+	}
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			p := geom.Vec2{X: x, Y: y}
+			if _, hit := firstHit[p]; !hit {
+				clear(p)
+			}
+		}
+	}
+
+	connected := func() bool {
+		return open[index(start)] && open[index(goal)] && sets.find(index(start)) == sets.find(index(goal))
+	}
+	sweep := func(hi, lo int) (int, bool) {
+		for i := hi; i >= lo; i-- {
+			pos := board.bytes[i]
+			if first, ok := firstHit[pos]; !ok || first != i {
+				continue
+			}
+			clear(pos)
+			if connected() {
+				return i, true
+			}
+		}
+		return 0, false
+	}
+	if connected() {
+		return 0, false
+	}
+	if i, ok := sweep(len(board.bytes)-1, from); ok {
+		return i, true
+	}
+	return sweep(from-1, 0)
+}
+
+// disjointSet is a union-find over the integers 0 to n-1.
+type disjointSet struct {
+	parent, rank []int
+}
+
+func newDisjointSet(n int) disjointSet {
+	parent := make([]int, n)
+	for i := range parent {
+		parent[i] = i
+	}
+	return disjointSet{parent, make([]int, n)}
+}
+
+func (s disjointSet) find(i int) int {
+	for s.parent[i] != i {
+		s.parent[i] = s.parent[s.parent[i]]
+		i = s.parent[i]
+	}
+	return i
+}
+
+func (s disjointSet) union(a, b int) {
+	a, b = s.find(a), s.find(b)
+	switch {
+	case a == b:
+	case s.rank[a] < s.rank[b]:
+		s.parent[a] = b
+	case s.rank[a] > s.rank[b]:
+		s.parent[b] = a
+	default:
+		s.parent[b] = a
+		s.rank[a]++
+	}
+}
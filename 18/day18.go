@@ -0,0 +1,237 @@
+package day18
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/grid"
+	"github.com/faideww/aoc-2024/lib/runner"
+	"github.com/faideww/aoc-2024/lib/search"
+)
+
+const (
+	EMPTY_TILE = iota
+	CORRUPTED_TILE
+)
+
+type Board struct {
+	bytes []geom.Vec2
+	tiles *grid.Grid[int]
+}
+
+// ARENAS are the puzzle's two setups, the sample's and the real one, and how
+// many bytes part 1 drops on each.
+var ARENAS = []struct{ size, bytes int }{
+	{7, 12},
+	{71, 1024},
+}
+
+// MAX_REPORTED is how many out-of-bounds lines parseBoard lists beyond the
+// first.
+const MAX_REPORTED = 10
+
+func init() {
+	runner.Register(18, newSolver())
+}
+
+func newSolver() *solver {
+	return &solver{blocking: "unionfind"}
+}
+
+type solver struct {
+	// bytesSimulated and arenaSize are worked out from the input when 0
+	bytesSimulated, arenaSize int
+	blocking                  string
+}
+
+// Configure accepts -size, the width and height of the arena, and -bytes,
+// how many bytes fall in part 1. Either left at 0 is picked from ARENAS by
+// the smallest arena that holds every byte. -blocking chooses how part 2
+// finds the first blocking byte: linear, binary or unionfind.
+func (s *solver) Configure(args []string) error {
+	fs := flag.NewFlagSet("day 18", flag.ContinueOnError)
+	fs.IntVar(&s.arenaSize, "size", s.arenaSize, "width and height of the arena, or 0 to detect it")
+	fs.IntVar(&s.bytesSimulated, "bytes", s.bytesSimulated, "bytes that fall in part 1, or 0 to detect it")
+	fs.StringVar(&s.blocking, "blocking", s.blocking, "how part 2 finds the blocking byte: linear, binary or unionfind")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() > 0 {
+		return fmt.Errorf("unexpected arguments %q; use -bytes and -size", fs.Args())
+	}
+	if _, ok := blockingFinders[s.blocking]; !ok {
+		return fmt.Errorf("unknown -blocking %q, expected linear, binary or unionfind", s.blocking)
+	}
+	if s.arenaSize < 0 || s.bytesSimulated < 0 {
+		return fmt.Errorf("-size and -bytes must not be negative")
+	}
+	return nil
+}
+
+// setup parses the input and settles the arena size and number of bytes for
+// part 1.
+func (s *solver) setup(input string) (Board, int, error) {
+	bytesSimulated := s.bytesSimulated
+	board, err := parseBoard(input, s.arenaSize)
+	if err != nil {
+		return Board{}, 0, err
+	}
+	if bytesSimulated == 0 {
+		size := board.tiles.Width
+		for _, a := range ARENAS {
+			if a.size == size {
+				bytesSimulated = a.bytes
+			}
+		}
+		if bytesSimulated == 0 {
+			return Board{}, 0, fmt.Errorf("no default byte count for a %dx%d arena; set one with -bytes", size, size)
+		}
+	}
+	if bytesSimulated > len(board.bytes) {
+		return Board{}, 0, fmt.Errorf("cannot simulate %d bytes, the input only has %d", bytesSimulated, len(board.bytes))
+	}
+	return board, bytesSimulated, nil
+}
+
+func (s *solver) Part1(input string) (runner.Answer, error) {
+	board, bytesSimulated, err := s.setup(input)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < bytesSimulated; i++ {
+		simulateByte(&board, i)
+	}
+
+	result, ok := findShortestPath(board)
+	if !ok {
+		return nil, fmt.Errorf("no path to the exit after %d bytes", bytesSimulated)
+	}
+	return runner.Int(result), nil
+}
+
+func (s *solver) Part2(input string) (runner.Answer, error) {
+	board, bytesSimulated, err := s.setup(input)
+	if err != nil {
+		return nil, err
+	}
+	i, ok := blockingFinders[s.blocking](board, bytesSimulated)
+	if !ok {
+		return nil, fmt.Errorf("no blocking bytes found")
+	}
+	blocker := board.bytes[i]
+	return runner.Text(fmt.Sprintf("%d,%d", blocker.X, blocker.Y)), nil
+}
+
+// parseBoard reads one X,Y byte position per line. An arenaSize of 0 picks
+// the smallest of ARENAS that holds every byte. Every byte that lands outside
+// the arena is reported.
+func parseBoard(input string, arenaSize int) (Board, error) {
+	lines := io.Lines(input)
+	bytes := make([]geom.Vec2, len(lines))
+	extent := 0
+	for i, line := range lines {
+		components := line.Split(",")
+		if len(components) != 2 {
+			return Board{}, line.Errorf(0, "expected X,Y coordinates, got %q", line.Text)
+		}
+		coords, err := io.Ints(components)
+		if err != nil {
+			return Board{}, err
+		}
+
+		bytes[i] = geom.Vec2{X: coords[0], Y: coords[1]}
+		extent = max(extent, coords[0]+1, coords[1]+1)
+	}
+
+	if arenaSize == 0 {
+		for _, a := range ARENAS {
+			if a.size >= extent {
+				arenaSize = a.size
+				break
+			}
+		}
+		if arenaSize == 0 {
+			return Board{}, fmt.Errorf("bytes reach coordinate %d, beyond every known arena; set the size with -size", extent-1)
+		}
+	}
+
+	tiles := grid.New[int](arenaSize, arenaSize)
+	outside := []int{}
+	for i, pos := range bytes {
+		if !tiles.In(pos) {
+			outside = append(outside, i)
+		}
+	}
+	if len(outside) > 0 {
+		first := bytes[outside[0]]
+		msg := fmt.Sprintf("byte at %d,%d is outside the %dx%d arena", first.X, first.Y, arenaSize, arenaSize)
+		if more := outside[1:]; len(more) > 0 {
+			nums := []string{}
+			for _, i := range more[:min(len(more), MAX_REPORTED)] {
+				nums = append(nums, strconv.Itoa(lines[i].Num))
+			}
+			if len(more) > MAX_REPORTED {
+				nums = append(nums, "...")
+			}
+			plural := "s"
+			if len(more) == 1 {
+				plural = ""
+			}
+			msg += fmt.Sprintf(", and %d more on line%s %s", len(more), plural, strings.Join(nums, ", "))
+		}
+		return Board{}, lines[outside[0]].Errorf(0, "%s", msg)
+	}
+
+	return Board{
+		bytes: bytes,
+		tiles: tiles,
+	}, nil
+}
+
+func simulateByte(board *Board, byteIndex int) {
+	board.tiles.Set(board.bytes[byteIndex], CORRUPTED_TILE)
+}
+
+func findShortestPath(board Board) (int, bool) {
+	start := geom.Vec2{X: 0, Y: 0}
+	goal := geom.Vec2{X: board.tiles.Width - 1, Y: board.tiles.Height - 1}
+
+	neighbors := func(current geom.Vec2) []geom.Vec2 {
+		open := make([]geom.Vec2, 0, 4)
+		for neighbor, tile := range board.tiles.Neighbors4(current) {
+			if tile != CORRUPTED_TILE {
+				open = append(open, neighbor)
+			}
+		}
+		return open
+	}
+	isGoal := func(current geom.Vec2) bool { return current == goal }
+
+	result, ok := search.BFS(start, neighbors, isGoal)
+	if !ok {
+		return -1, false
+	}
+
+	// printBoard(board, result.Path())
+
+	return result.Cost, true
+}
+
+func printBoard(board Board, path []geom.Vec2) {
+	onPath := make(map[geom.Vec2]bool)
+	for _, p := range path {
+		onPath[p] = true
+	}
+	fmt.Print(board.tiles.Format(func(p geom.Vec2, tile int) string {
+		if tile == CORRUPTED_TILE {
+			return "#"
+		} else if onPath[p] {
+			return "O"
+		}
+		return "."
+	}))
+}
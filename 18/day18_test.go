@@ -0,0 +1,77 @@
+package day18
+
+import (
+	"strings"
+	"testing"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, newSolver, []runnertest.Case{
+		{Input: "test.txt", Part1: "22", Part2: "6,1"},
+		{Input: "in.txt", Part1: "268", Part2: "64,11"},
+	})
+}
+
+func TestBlockingFindersAgree(t *testing.T) {
+	tests := []struct {
+		name  string
+		input string
+		size  int
+		from  int
+		want  int
+		// false when no byte blocks the path
+		ok bool
+	}{
+		{name: "test", input: "test.txt", size: 7, from: 12, want: 20, ok: true},
+		{name: "test from start", input: "test.txt", size: 7, from: 0, want: 20, ok: true},
+		{name: "test blocked at from", input: "test.txt", size: 7, from: 22, want: 20, ok: true},
+		{name: "test every byte", input: "test.txt", size: 7, from: 25, want: 20, ok: true},
+		{name: "in", input: "in.txt", size: 71, from: 1024, want: 2933, ok: true},
+		{name: "never blocked", input: "1,0\n1,1", size: 3, from: 1, ok: false},
+	}
+	for _, tt := range tests {
+		input := tt.input
+		if strings.HasSuffix(input, ".txt") {
+			var err error
+			if input, err = io.ReadInputFile(input); err != nil {
+				t.Fatal(err)
+			}
+		}
+		board, err := parseBoard(input, tt.size)
+		if err != nil {
+			t.Fatalf("%s: %v", tt.name, err)
+		}
+		for name, find := range blockingFinders {
+			got, ok := find(board, tt.from)
+			if ok != tt.ok || (ok && got != tt.want) {
+				t.Errorf("%s: %s finder = %d, %t, want %d, %t", tt.name, name, got, ok, tt.want, tt.ok)
+			}
+		}
+	}
+}
+
+func BenchmarkBlockingByte(b *testing.B) {
+	input, err := io.ReadInputFile("in.txt")
+	if err != nil {
+		b.Fatal(err)
+	}
+	board, err := parseBoard(input, 71)
+	if err != nil {
+		b.Fatal(err)
+	}
+	want, _ := findBlockingLinear(board, 1024)
+
+	for _, name := range []string{"linear", "binary", "unionfind"} {
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if got, ok := blockingFinders[name](board, 1024); !ok || got != want {
+					b.Fatalf("got byte %d, want %d", got, want)
+				}
+			}
+		})
+	}
+}
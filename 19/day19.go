@@ -1,10 +1,11 @@
-package main
+package day19
 
 import (
 	"fmt"
-	"os"
+	"strings"
 
 	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner"
 )
 
 type TrieNode struct {
@@ -19,34 +20,59 @@ func NewTrieNode() *TrieNode {
 	}
 }
 
-func main() {
-	input := io.ReadInputFile(os.Args[1])
+func init() {
+	runner.Register(19, solver{})
+}
+
+type solver struct{}
 
-	trie, words := parseInput(input)
+func (solver) Part1(input string) (runner.Answer, error) {
+	trie, words, err := parseInput(input)
+	if err != nil {
+		return nil, err
+	}
 
 	sum := 0
 	for _, word := range words {
-		isPossible := isPatternPossible(trie, word)
-		if isPossible {
+		if isPatternPossible(trie, word) {
 			sum++
 		}
 	}
+	return runner.Int(sum), nil
+}
 
-	fmt.Printf("possibles: %d\n", sum)
+func (solver) Part2(input string) (runner.Answer, error) {
+	trie, words, err := parseInput(input)
+	if err != nil {
+		return nil, err
+	}
 
-	sum2 := 0
+	sum := 0
 	for _, word := range words {
-		sum2 += countPossiblePatterns(trie, word)
+		sum += countPossiblePatterns(trie, word)
 	}
-
-	fmt.Printf("all possibles: %d\n", sum2)
+	return runner.Int(sum), nil
 }
 
-func parseInput(input string) (*TrieNode, []string) {
-	components := io.TrimAndSplitBy(input, "\n\n")
+func parseInput(input string) (*TrieNode, []string, error) {
+	sections := io.Sections(input)
+	if len(sections) != 2 || len(sections[0]) != 1 {
+		return nil, nil, fmt.Errorf("expected a line of towel patterns, a blank line and a list of designs")
+	}
+
+	stripes := []string{}
+	for _, field := range sections[0][0].Split(",") {
+		stripe := strings.TrimSpace(field.Text)
+		if stripe == "" {
+			return nil, nil, field.Errorf("empty towel pattern")
+		}
+		stripes = append(stripes, stripe)
+	}
 
-	stripes := io.TrimAndSplitBy(components[0], ", ")
-	words := io.TrimAndSplit(components[1])
+	words := make([]string, len(sections[1]))
+	for i, line := range sections[1] {
+		words[i] = line.Text
+	}
 
 	// build trie
 	root := NewTrieNode()
@@ -63,7 +89,7 @@ func parseInput(input string) (*TrieNode, []string) {
 		current.isTerminal = true
 	}
 
-	return root, words
+	return root, words, nil
 }
 
 func isPatternPossible(trieRoot *TrieNode, pattern string) bool {
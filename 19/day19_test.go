@@ -0,0 +1,14 @@
+package day19
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "6", Part2: "16"},
+		{Input: "in.txt", Part1: "206", Part2: "622121814629343"},
+	})
+}
@@ -0,0 +1,101 @@
+package day2
+
+import (
+	"slices"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+func init() {
+	runner.Register(2, solver{})
+}
+
+type solver struct{}
+
+func (solver) Part1(input string) (runner.Answer, error) {
+	reports, err := parseReports(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(computeSafeReports(reports)), nil
+}
+
+func (solver) Part2(input string) (runner.Answer, error) {
+	reports, err := parseReports(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(computeSafeReportsWithTolerance(reports)), nil
+}
+
+func parseReports(input string) ([][]int, error) {
+	lines := io.Lines(input)
+	reports := make([][]int, len(lines))
+	for i, line := range lines {
+		levels, err := line.Ints()
+		if err != nil {
+			return nil, err
+		}
+		if len(levels) < 2 {
+			return nil, line.Errorf(0, "expected at least 2 levels, got %d", len(levels))
+		}
+		reports[i] = levels
+	}
+	return reports, nil
+}
+
+func computeSafeReports(reports [][]int) int {
+	sum := 0
+	for _, levels := range reports {
+		if isReportSafe(levels) {
+			sum++
+		}
+	}
+
+	return sum
+}
+
+func computeSafeReportsWithTolerance(reports [][]int) int {
+	sum := 0
+	for _, levels := range reports {
+		if isReportSafe(levels) {
+			sum++
+		} else {
+			for j := 0; j < len(levels); j++ {
+				// annoying slice manipulation (append modifies the underlying
+				// array for performance reasons)
+				levelsWithoutBadIndex := slices.Delete(slices.Clone(levels), j, j+1)
+				if isReportSafe(levelsWithoutBadIndex) {
+					sum++
+					break
+				}
+			}
+		}
+	}
+
+	return sum
+}
+
+func isReportSafe(levels []int) bool {
+	var inc bool
+	if levels[0] < levels[1] {
+		inc = true
+	} else if levels[0] > levels[1] {
+		inc = false
+	} else {
+		return false
+	}
+
+	for i := 1; i < len(levels); i++ {
+		if (inc && levels[i-1] >= levels[i]) || (!inc && levels[i-1] <= levels[i]) {
+			return false
+		}
+
+		if levels[i-1]-levels[i] > 3 || levels[i-1]-levels[i] < -3 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,14 @@
+package day2
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "2", Part2: "4"},
+		{Input: "in.txt", Part1: "524", Part2: "569"},
+	})
+}
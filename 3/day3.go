@@ -0,0 +1,95 @@
+package day3
+
+import (
+	"regexp"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+func init() {
+	runner.Register(3, solver{})
+}
+
+type solver struct{}
+
+func (solver) Part1(input string) (runner.Answer, error) {
+	sum, err := computeValidMuls(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(sum), nil
+}
+
+func (solver) Part2(input string) (runner.Answer, error) {
+	sum, err := computeValidMulsStateful(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(sum), nil
+}
+
+// findInstructions returns the capture groups of every match of r in the
+// input. Instructions never span lines, so we can match line by line and keep
+// track of where each operand came from.
+func findInstructions(input string, r *regexp.Regexp) [][]io.Field {
+	instructions := [][]io.Field{}
+	for _, line := range io.Lines(input) {
+		instructions = append(instructions, line.MatchAll(r)...)
+	}
+	return instructions
+}
+
+func mul(a, b io.Field) (int, error) {
+	v1, err := a.Int()
+	if err != nil {
+		return 0, err
+	}
+	v2, err := b.Int()
+	if err != nil {
+		return 0, err
+	}
+	return v1 * v2, nil
+}
+
+func computeValidMuls(input string) (int, error) {
+	r := regexp.MustCompile(`mul\((\d+),(\d+)\)`)
+
+	sum := 0
+	for _, match := range findInstructions(input, r) {
+		product, err := mul(match[0], match[1])
+		if err != nil {
+			return 0, err
+		}
+		sum += product
+	}
+
+	return sum, nil
+}
+
+func computeValidMulsStateful(input string) (int, error) {
+
+	r := regexp.MustCompile(`(mul|do|don't)\((?:(\d+),(\d+))?\)`)
+
+	sum := 0
+	on := true
+	for _, match := range findInstructions(input, r) {
+		switch match[0].Text {
+		case "mul":
+			// a bare "mul()" isn't a valid instruction
+			if on && match[1].Text != "" {
+				product, err := mul(match[1], match[2])
+				if err != nil {
+					return 0, err
+				}
+				sum += product
+			}
+		case "do":
+			on = true
+		case "don't":
+			on = false
+		}
+	}
+
+	return sum, nil
+}
@@ -0,0 +1,15 @@
+package day3
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "161", Part2: "161"},
+		{Input: "test2.txt", Part1: "161", Part2: "48"},
+		{Input: "in.txt", Part1: "165225049", Part2: "108830766"},
+	})
+}
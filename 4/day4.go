@@ -0,0 +1,77 @@
+package day4
+
+import (
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/grid"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+func init() {
+	runner.Register(4, solver{})
+}
+
+type solver struct{}
+
+func (solver) Part1(input string) (runner.Answer, error) {
+	g, err := grid.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(doXmasWordSearch(g)), nil
+}
+
+func (solver) Part2(input string) (runner.Answer, error) {
+	g, err := grid.Parse(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(doXDashMasWordSearch(g)), nil
+}
+
+// matchesWord reports whether word can be read starting at start and moving
+// in the direction of step.
+func matchesWord(g *grid.Grid[rune], start, step geom.Vec2, word string) bool {
+	i := 0
+	for _, char := range g.Walk(start, step) {
+		if char != rune(word[i]) {
+			return false
+		}
+		i++
+		if i == len(word) {
+			return true
+		}
+	}
+	return false
+}
+
+func doXmasWordSearch(g *grid.Grid[rune]) int {
+	// every occurrence starts on an X, so look outward from each X in all 8
+	// directions. reversed words are found by looking in the opposite direction
+	sum := 0
+	for _, start := range grid.FindAll(g, 'X') {
+		for _, step := range grid.Offsets8 {
+			if matchesWord(g, start, step, "XMAS") {
+				sum++
+			}
+		}
+	}
+
+	return sum
+}
+
+func doXDashMasWordSearch(g *grid.Grid[rune]) int {
+	sum := 0
+	for _, center := range grid.FindAll(g, 'A') {
+		// each diagonal through the A must read MAS in one direction or the other
+		downRight := geom.Vec2{X: center.X - 1, Y: center.Y - 1}
+		upRight := geom.Vec2{X: center.X - 1, Y: center.Y + 1}
+		downRightOk := matchesWord(g, downRight, geom.Vec2{X: 1, Y: 1}, "MAS") || matchesWord(g, downRight, geom.Vec2{X: 1, Y: 1}, "SAM")
+		upRightOk := matchesWord(g, upRight, geom.Vec2{X: 1, Y: -1}, "MAS") || matchesWord(g, upRight, geom.Vec2{X: 1, Y: -1}, "SAM")
+
+		if downRightOk && upRightOk {
+			sum++
+		}
+	}
+
+	return sum
+}
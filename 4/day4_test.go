@@ -0,0 +1,14 @@
+package day4
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "18", Part2: "9"},
+		{Input: "in.txt", Part1: "2500", Part2: "1933"},
+	})
+}
@@ -1,36 +1,71 @@
-package main
+package day5
 
 import (
 	"fmt"
-	"os"
-	"strconv"
-	"strings"
 
 	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner"
 )
 
-func main() {
-	input := io.ReadInputFile(os.Args[1])
-	parts := io.TrimAndSplitBy(input, "\n\n")
-	rules, updates := parts[0], parts[1]
+func init() {
+	runner.Register(5, solver{})
+}
+
+type solver struct{}
+
+func (solver) Part1(input string) (runner.Answer, error) {
+	correctResult, _, err := solveUpdates(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(correctResult), nil
+}
+
+func (solver) Part2(input string) (runner.Answer, error) {
+	_, incorrectResult, err := solveUpdates(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(incorrectResult), nil
+}
+
+func solveUpdates(input string) (int, int, error) {
+	sections := io.Sections(input)
+	if len(sections) != 2 {
+		return 0, 0, fmt.Errorf("expected rules and updates separated by a blank line, got %d sections", len(sections))
+	}
+	rules, updates := sections[0], sections[1]
 
-	prereqs := computePrerequisitePages(io.TrimAndSplit(rules))
+	prereqs, err := computePrerequisitePages(rules)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	pageLists, err := parseUpdates(updates)
+	if err != nil {
+		return 0, 0, err
+	}
 
-	correctResult, incorrectResult := computeUpdates(io.TrimAndSplit(updates), prereqs)
-	fmt.Printf("valid updates sum: %d\n", correctResult)
-	fmt.Printf("invalid updates sum: %d\n", incorrectResult)
+	correctSum, incorrectSum := computeUpdates(pageLists, prereqs)
+	return correctSum, incorrectSum, nil
 }
 
-func computePrerequisitePages(rules []string) map[int][]int {
+func computePrerequisitePages(rules []io.Line) (map[int][]int, error) {
 	prereqs := make(map[int][]int)
 	// for each rule:
 	// the first page must be printed before the second page
 	// prereqs is a map keyed by each page, and the value is a list of the pages that must be printed before it
 
-	for _, ruleStr := range rules {
-		pages := strings.Split(ruleStr, "|")
-		requiredPage, _ := strconv.Atoi(pages[0])
-		page, _ := strconv.Atoi(pages[1])
+	for _, rule := range rules {
+		fields := rule.Split("|")
+		if len(fields) != 2 {
+			return nil, rule.Errorf(0, "expected rule of the form X|Y, got %q", rule.Text)
+		}
+		pages, err := io.Ints(fields)
+		if err != nil {
+			return nil, err
+		}
+		requiredPage, page := pages[0], pages[1]
 
 		if _, ok := prereqs[page]; !ok {
 			prereqs[page] = []int{}
@@ -38,23 +73,31 @@ func computePrerequisitePages(rules []string) map[int][]int {
 		prereqs[page] = append(prereqs[page], requiredPage)
 	}
 
-	return prereqs
+	return prereqs, nil
 }
 
-func computeUpdates(updates []string, pageTree map[int][]int) (int, int) {
+func parseUpdates(updates []io.Line) ([][]int, error) {
+	pageLists := make([][]int, len(updates))
+	for i, update := range updates {
+		pages, err := io.Ints(update.Split(","))
+		if err != nil {
+			return nil, err
+		}
+		pageLists[i] = pages
+	}
+	return pageLists, nil
+}
+
+func computeUpdates(updates [][]int, pageTree map[int][]int) (int, int) {
 	correctSum := 0
 	incorrectSum := 0
-	for _, updateStr := range updates {
+	for _, pages := range updates {
 		// for each page, we store the index of that page in a map.
 		// after the map is built, we iterate over the pages again and
 		// check that every prerequisite page (as dictated by pageTree)
 		// does not have a higher index in the order map.
-		pagesStr := strings.Split(updateStr, ",")
-		pages := make([]int, len(pagesStr))
 		orderMap := make(map[int]int)
-		for i, pageStr := range pagesStr {
-			pageNum, _ := strconv.Atoi(pageStr)
-			pages[i] = pageNum
+		for i, pageNum := range pages {
 			orderMap[pageNum] = i
 		}
 
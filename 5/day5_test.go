@@ -0,0 +1,14 @@
+package day5
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "143", Part2: "123"},
+		{Input: "in.txt", Part1: "6505", Part2: "6897"},
+	})
+}
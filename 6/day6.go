@@ -0,0 +1,175 @@
+package day6
+
+import (
+	"fmt"
+
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/grid"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+type GuardState struct {
+	pos    geom.Vec2
+	facing geom.Dir
+}
+
+type Board struct {
+	obstacles  *grid.Grid[bool]
+	guardState GuardState
+}
+
+func init() {
+	runner.Register(6, solver{})
+}
+
+type solver struct{}
+
+func (solver) Part1(input string) (runner.Answer, error) {
+	board, err := parseBoard(input)
+	if err != nil {
+		return nil, err
+	}
+	uniqueTiles := traceGuardPath(board)
+	return runner.Int(len(uniqueTiles)), nil
+}
+
+func (solver) Part2(input string) (runner.Answer, error) {
+	board, err := parseBoard(input)
+	if err != nil {
+		return nil, err
+	}
+	uniqueTiles := traceGuardPath(board)
+	return runner.Int(findLoops(board, uniqueTiles)), nil
+}
+
+func parseBoard(input string) (Board, error) {
+	guardState := GuardState{facing: geom.North}
+	foundGuard := false
+	obstacles, err := grid.ParseFunc(input, func(p geom.Vec2, char rune) (bool, error) {
+		switch char {
+		case '.':
+		case '#':
+			return true, nil
+		case '^':
+			if foundGuard {
+				return false, fmt.Errorf("found a second guard")
+			}
+			guardState.pos = p
+			foundGuard = true
+		default:
+			return false, fmt.Errorf("unexpected tile %q", char)
+		}
+		return false, nil
+	})
+	if err != nil {
+		return Board{}, err
+	}
+
+	if !foundGuard {
+		return Board{}, fmt.Errorf("no guard (^) found on the map")
+	}
+
+	return Board{
+		obstacles:  obstacles,
+		guardState: guardState,
+	}, nil
+}
+
+// traceGuardPath returns every tile the guard visits, in the order they are
+// first visited.
+func traceGuardPath(board Board) []geom.Vec2 {
+	seenTiles := grid.New[bool](board.obstacles.Width, board.obstacles.Height)
+	currentGuardState := board.guardState
+
+	seenTiles.Set(currentGuardState.pos, true)
+	path := []geom.Vec2{currentGuardState.pos}
+
+	for {
+		nextGuardState, ok := stepGuard(board, currentGuardState)
+		if !ok {
+			break
+		}
+
+		if !seenTiles.Get(nextGuardState.pos) {
+			seenTiles.Set(nextGuardState.pos, true)
+			path = append(path, nextGuardState.pos)
+		}
+
+		currentGuardState = nextGuardState
+	}
+
+	return path
+}
+
+func stepGuard(board Board, guardState GuardState) (GuardState, bool) {
+	// step guard routine
+	nextTile := guardState.pos.Add(guardState.facing.Vec())
+
+	blocked, ok := board.obstacles.Lookup(nextTile)
+	if !ok {
+		// next tile takes us out of bounds, exit
+		return guardState, false
+	}
+
+	if blocked {
+		return GuardState{
+			pos:    guardState.pos,
+			facing: guardState.facing.TurnRight(),
+		}, true
+	} else {
+		return GuardState{
+			pos:    nextTile,
+			facing: guardState.facing,
+		}, true
+	}
+}
+
+func findLoops(board Board, guardPath []geom.Vec2) int {
+	// Hypothesis: we don't need to test all tiles in the map because we know the
+	// guard's path already. we only need to test placing obstacles in the
+	// original path.
+
+	loopsFound := 0
+
+	// seen records, per tile and facing, the last attempt in which the guard
+	// was in that state. tagging states with the attempt number means we never
+	// need to clear the grid between attempts.
+	seen := grid.New[[4]int](board.obstacles.Width, board.obstacles.Height)
+
+	for i, pos := range guardPath {
+		if pos == board.guardState.pos {
+			continue
+		}
+		attempt := i + 1
+
+		board.obstacles.Set(pos, true)
+
+		foundLoop := false
+		currentGuardState := board.guardState
+		for {
+			states := seen.Get(currentGuardState.pos)
+			if states[currentGuardState.facing] == attempt {
+				foundLoop = true
+				break
+			}
+			states[currentGuardState.facing] = attempt
+			seen.Set(currentGuardState.pos, states)
+
+			nextGuardState, ok := stepGuard(board, currentGuardState)
+			if !ok {
+				break
+			}
+
+			currentGuardState = nextGuardState
+		}
+
+		if foundLoop {
+			loopsFound++
+		}
+
+		// reset obstacle
+		board.obstacles.Set(pos, false)
+	}
+
+	return loopsFound
+}
@@ -0,0 +1,14 @@
+package day6
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "41", Part2: "6"},
+		{Input: "in.txt", Part1: "4939", Part2: "1434"},
+	})
+}
@@ -0,0 +1,94 @@
+package day7
+
+import (
+	"fmt"
+	"strconv"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+func init() {
+	runner.Register(7, solver{})
+}
+
+type solver struct{}
+
+func (solver) Part1(input string) (runner.Answer, error) {
+	equations, err := parseEquations(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(findValidEquations(equations, false)), nil
+}
+
+func (solver) Part2(input string) (runner.Answer, error) {
+	equations, err := parseEquations(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(findValidEquations(equations, true)), nil
+}
+
+type Equation struct {
+	target   int
+	operands []int
+}
+
+func parseEquations(input string) ([]Equation, error) {
+	lines := io.Lines(input)
+	equations := make([]Equation, len(lines))
+	for i, line := range lines {
+		sides := line.Split(":")
+		if len(sides) != 2 {
+			return nil, line.Errorf(0, "expected <target>: <operands>, got %q", line.Text)
+		}
+		target, err := sides[0].Int()
+		if err != nil {
+			return nil, err
+		}
+		operands, err := io.Ints(sides[1].Fields())
+		if err != nil {
+			return nil, err
+		}
+		if len(operands) == 0 {
+			return nil, line.Errorf(0, "equation has no operands")
+		}
+		equations[i] = Equation{target, operands}
+	}
+	return equations, nil
+}
+
+func findValidEquations(equations []Equation, withConcat bool) (sum int) {
+	for _, eq := range equations {
+		if searchValidEquation(eq.target, eq.operands, eq.operands[0], 1, withConcat) {
+			sum += eq.target
+		}
+	}
+	return sum
+}
+
+func searchValidEquation(target int, operands []int, currentValue int, idx int, withConcat bool) bool {
+	// recursively reduce the equation by applying one of the four operators until we find an equality
+
+	if idx == len(operands) {
+		return target == currentValue
+	}
+
+	// addition
+	if searchValidEquation(target, operands, currentValue+operands[idx], idx+1, withConcat) {
+		return true
+	}
+
+	// multiplication
+	if searchValidEquation(target, operands, currentValue*operands[idx], idx+1, withConcat) {
+		return true
+	}
+
+	// concatenation (pt 2)
+	if !withConcat {
+		return false
+	}
+	nextValue, _ := strconv.Atoi(fmt.Sprintf("%d%d", currentValue, operands[idx]))
+	return searchValidEquation(target, operands, nextValue, idx+1, withConcat)
+}
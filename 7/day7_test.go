@@ -0,0 +1,14 @@
+package day7
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "3749", Part2: "11387"},
+		{Input: "in.txt", Part1: "2654749936343", Part2: "124060392153684"},
+	})
+}
@@ -0,0 +1,119 @@
+package day8
+
+import (
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/grid"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+type Board struct {
+	tiles    *grid.Grid[rune]
+	antennae map[rune][]geom.Vec2
+}
+
+func init() {
+	runner.Register(8, solver{})
+}
+
+type solver struct{}
+
+func (solver) Part1(input string) (runner.Answer, error) {
+	board, err := parseBoard(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(countAntinodes(board)), nil
+}
+
+func (solver) Part2(input string) (runner.Answer, error) {
+	board, err := parseBoard(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(countAllAntinodes(board)), nil
+}
+
+func parseBoard(input string) (Board, error) {
+	tiles, err := grid.Parse(input)
+	if err != nil {
+		return Board{}, err
+	}
+
+	antennae := make(map[rune][]geom.Vec2)
+	for pos, char := range tiles.All() {
+		if char == '.' {
+			continue
+		}
+		antennae[char] = append(antennae[char], pos)
+	}
+
+	return Board{
+		tiles:    tiles,
+		antennae: antennae,
+	}, nil
+}
+
+func countAntinodes(board Board) int {
+	antinodes := grid.New[bool](board.tiles.Width, board.tiles.Height)
+	for freq := range board.antennae {
+		findAntinodes(board, freq, antinodes)
+	}
+
+	return len(grid.FindAll(antinodes, true))
+}
+
+func findAntinodes(board Board, freq rune, antinodes *grid.Grid[bool]) {
+	// iterate through all pairs of antennae and compute the antinodes
+	antennae := board.antennae[freq]
+
+	for i := 0; i < len(antennae); i++ {
+		node1 := antennae[i]
+		for j := i + 1; j < len(antennae); j++ {
+			node2 := antennae[j]
+			delta := node2.Sub(node1)
+
+			antinode1 := node2.Add(delta)
+
+			if antinodes.In(antinode1) {
+				antinodes.Set(antinode1, true)
+			}
+
+			antinode2 := node1.Sub(delta)
+
+			if antinodes.In(antinode2) {
+				antinodes.Set(antinode2, true)
+			}
+		}
+	}
+}
+
+func countAllAntinodes(board Board) int {
+	antinodes := grid.New[bool](board.tiles.Width, board.tiles.Height)
+	for freq := range board.antennae {
+		findAllAntinodes(board, freq, antinodes)
+	}
+
+	return len(grid.FindAll(antinodes, true))
+}
+
+func findAllAntinodes(board Board, freq rune, antinodes *grid.Grid[bool]) {
+	antennae := board.antennae[freq]
+
+	for i := 0; i < len(antennae); i++ {
+		node1 := antennae[i]
+		for j := i + 1; j < len(antennae); j++ {
+			node2 := antennae[j]
+			delta := node2.Sub(node1)
+
+			// count antinodes in 1 direction
+			for pos := range antinodes.Walk(node2, delta) {
+				antinodes.Set(pos, true)
+			}
+
+			// now count them in the other direction
+			for pos := range antinodes.Walk(node1, delta.Neg()) {
+				antinodes.Set(pos, true)
+			}
+		}
+	}
+}
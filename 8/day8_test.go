@@ -0,0 +1,14 @@
+package day8
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "14", Part2: "34"},
+		{Input: "in.txt", Part1: "254", Part2: "951"},
+	})
+}
@@ -1,11 +1,10 @@
-package main
+package day9
 
 import (
 	"fmt"
-	"os"
-	"strconv"
 
 	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner"
 )
 
 type File struct {
@@ -19,27 +18,45 @@ type Disk struct {
 	files  []File
 }
 
-func main() {
-	input := io.ReadInputFile(os.Args[1])
+func init() {
+	runner.Register(9, solver{})
+}
 
-	disk := parseDisk(input)
-	checksum := computeCompactedChecksum(disk)
+type solver struct{}
 
-	fmt.Printf("checksum: %d\n", checksum)
+func (solver) Part1(input string) (runner.Answer, error) {
+	disk, err := parseDisk(input)
+	if err != nil {
+		return nil, err
+	}
+	return runner.Int(computeCompactedChecksum(disk)), nil
+}
 
+func (solver) Part2(input string) (runner.Answer, error) {
+	disk, err := parseDisk(input)
+	if err != nil {
+		return nil, err
+	}
 	defragmentDisk(&disk)
-	defraggedChecksum := computeChecksum(disk)
-	fmt.Printf("defragged checksum: %d\n", defraggedChecksum)
+	return runner.Int(computeChecksum(disk)), nil
 }
 
-func parseDisk(input string) Disk {
+func parseDisk(input string) (Disk, error) {
+	lines := io.Lines(input)
+	if len(lines) != 1 {
+		return Disk{}, lines[1].Errorf(0, "expected the disk map on a single line")
+	}
+	values, err := lines[0].Digits()
+	if err != nil {
+		return Disk{}, err
+	}
+
 	files := []File{}
 
 	currentOffset := 0
 	currentId := 0
 	fileNext := true
-	for i := 0; i < len(input); i++ {
-		value, _ := strconv.Atoi(input[i : i+1])
+	for _, value := range values {
 		if !fileNext {
 			fileNext = true
 		} else {
@@ -57,7 +74,7 @@ func parseDisk(input string) Disk {
 	return Disk{
 		length: currentOffset,
 		files:  files,
-	}
+	}, nil
 }
 
 func printDisk(disk Disk) {
@@ -117,8 +134,6 @@ func computeCompactedChecksum(disk Disk) int {
 		}
 	}
 
-	fmt.Printf("\n")
-
 	return checksum
 }
 
@@ -0,0 +1,14 @@
+package day9
+
+import (
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/runner/runnertest"
+)
+
+func TestAnswers(t *testing.T) {
+	runnertest.Run(t, func() solver { return solver{} }, []runnertest.Case{
+		{Input: "test.txt", Part1: "1928", Part2: "2858"},
+		{Input: "in.txt", Part1: "6461289671426", Part2: "6488291456470"},
+	})
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"slices"
+	"text/tabwriter"
+	"time"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+type partKey struct{ day, part int }
+
+func benchCommand(args []string) error {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to time (0 times every registered day)")
+	part := fs.Int("part", 0, "part to time (0 times both)")
+	input := fs.String("input", "", "puzzle input file (defaults to <day>/in.txt)")
+	runs := fs.Int("n", 10, "number of times to solve each part")
+	sortBy := fs.String("sort", "time", "order of the table: time, allocs or day")
+	save := fs.String("save", "", "write the results to this JSON file")
+	compare := fs.String("compare", "", "compare against results saved earlier with -save")
+	fs.Parse(args)
+
+	var previous map[partKey]runner.Timing
+	if *compare != "" {
+		var err error
+		if previous, err = loadTimings(*compare); err != nil {
+			return err
+		}
+	}
+
+	days, err := selectDays(*day, *input, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	timings := []runner.Timing{}
+	for _, d := range days {
+		s, in, path, err := loadDay(d, *input, fs.Args())
+		if err != nil {
+			return err
+		}
+
+		for _, p := range selectParts(*part) {
+			t, err := runner.Benchmark(s, d, p, in, *runs)
+			if err != nil {
+				return fmt.Errorf("day %d part %d: %w", d, p, io.WithFile(err, path))
+			}
+			timings = append(timings, t)
+		}
+	}
+
+	if err := sortTimings(timings, *sortBy); err != nil {
+		return err
+	}
+	printTimings(timings, previous)
+
+	if *save != "" {
+		data, err := json.MarshalIndent(timings, "", "  ")
+		if err != nil {
+			return err
+		}
+		return os.WriteFile(*save, append(data, '\n'), 0644)
+	}
+	return nil
+}
+
+func loadTimings(path string) (map[partKey]runner.Timing, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var timings []runner.Timing
+	if err := json.Unmarshal(data, &timings); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	byPart := make(map[partKey]runner.Timing)
+	for _, t := range timings {
+		byPart[partKey{t.Day, t.Part}] = t
+	}
+	return byPart, nil
+}
+
+func sortTimings(timings []runner.Timing, by string) error {
+	byDay := func(a, b runner.Timing) int {
+		if a.Day != b.Day {
+			return a.Day - b.Day
+		}
+		return a.Part - b.Part
+	}
+
+	switch by {
+	case "day":
+		slices.SortFunc(timings, byDay)
+	case "time":
+		// slowest first
+		slices.SortStableFunc(timings, func(a, b runner.Timing) int {
+			return int(b.Median - a.Median)
+		})
+	case "allocs":
+		slices.SortStableFunc(timings, func(a, b runner.Timing) int {
+			return int(b.Allocs) - int(a.Allocs)
+		})
+	default:
+		return fmt.Errorf("cannot sort by %q, want time, allocs or day", by)
+	}
+	return nil
+}
+
+// printTimings draws the results as a table. If previous results are given,
+// each part's median is compared with its old median.
+func printTimings(timings []runner.Timing, previous map[partKey]runner.Timing) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	header := "day\tpart\tmedian\tmin\tmean\tallocs\tbytes\t"
+	if previous != nil {
+		header += "old median\tchange\t"
+	}
+	fmt.Fprintln(w, header)
+
+	var total time.Duration
+	for _, t := range timings {
+		total += t.Median
+		fmt.Fprintf(w, "%d\t%d\t%s\t%s\t%s\t%d\t%s\t",
+			t.Day, t.Part, formatDuration(t.Median), formatDuration(t.Min), formatDuration(t.Mean),
+			t.Allocs, formatBytes(t.Bytes))
+		if previous != nil {
+			if old, ok := previous[partKey{t.Day, t.Part}]; ok {
+				change := 100 * (float64(t.Median) - float64(old.Median)) / float64(old.Median)
+				fmt.Fprintf(w, "%s\t%+.1f%%\t", formatDuration(old.Median), change)
+			} else {
+				fmt.Fprint(w, "-\t-\t")
+			}
+		}
+		fmt.Fprintln(w)
+	}
+	fmt.Fprintf(w, "total\t\t%s\t\n", formatDuration(total))
+	w.Flush()
+}
+
+func formatDuration(d time.Duration) string {
+	switch {
+	case d >= time.Second:
+		return d.Round(time.Millisecond).String()
+	case d >= time.Millisecond:
+		return d.Round(10 * time.Microsecond).String()
+	}
+	return d.Round(time.Microsecond).String()
+}
+
+func formatBytes(b uint64) string {
+	const unit = 1024
+	if b < unit {
+		return fmt.Sprintf("%dB", b)
+	}
+	value, suffix := float64(b)/unit, "KiB"
+	for _, s := range []string{"MiB", "GiB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, s
+	}
+	return fmt.Sprintf("%.1f%s", value, suffix)
+}
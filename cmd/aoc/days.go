@@ -0,0 +1,24 @@
+package main
+
+// Each day registers its solver with the runner on import.
+import (
+	_ "github.com/faideww/aoc-2024/1"
+	_ "github.com/faideww/aoc-2024/10"
+	_ "github.com/faideww/aoc-2024/11"
+	_ "github.com/faideww/aoc-2024/12"
+	_ "github.com/faideww/aoc-2024/13"
+	_ "github.com/faideww/aoc-2024/14"
+	_ "github.com/faideww/aoc-2024/15"
+	_ "github.com/faideww/aoc-2024/16"
+	_ "github.com/faideww/aoc-2024/17"
+	_ "github.com/faideww/aoc-2024/18"
+	_ "github.com/faideww/aoc-2024/19"
+	_ "github.com/faideww/aoc-2024/2"
+	_ "github.com/faideww/aoc-2024/3"
+	_ "github.com/faideww/aoc-2024/4"
+	_ "github.com/faideww/aoc-2024/5"
+	_ "github.com/faideww/aoc-2024/6"
+	_ "github.com/faideww/aoc-2024/7"
+	_ "github.com/faideww/aoc-2024/8"
+	_ "github.com/faideww/aoc-2024/9"
+)
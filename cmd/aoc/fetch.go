@@ -0,0 +1,84 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/faideww/aoc-2024/lib/aoc"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+func fetchCommand(args []string) error {
+	fs := flag.NewFlagSet("fetch", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to download (0 downloads every registered day without an input)")
+	sessionFile := fs.String("session-file", "", "file holding the session token (defaults to <user config dir>/aoc/session; $"+aoc.SessionEnv+" wins)")
+	baseURL := fs.String("base-url", "", "site to download from (defaults to $"+aoc.BaseURLEnv+" or "+aoc.DefaultBaseURL+")")
+	ledgerPath := fs.String("ledger", "", "file recording past guesses and the last request (defaults to <user config dir>/aoc/ledger.json)")
+	fs.Parse(args)
+
+	days := runner.Days()
+	if *day != 0 {
+		days = []int{*day}
+	}
+
+	client, err := newClient(*sessionFile, *baseURL, *ledgerPath)
+	if err != nil {
+		return err
+	}
+
+	for _, d := range days {
+		path := aoc.InputPath(d)
+		if _, err := os.Stat(path); err == nil {
+			if *day != 0 {
+				return fmt.Errorf("%s already exists, delete it to download again", path)
+			}
+			continue
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		input, err := client.Input(d)
+		if err != nil {
+			return fmt.Errorf("day %d: %w", d, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, []byte(input), 0644); err != nil {
+			return err
+		}
+		fmt.Printf("day %d: wrote %s\n", d, path)
+	}
+	return nil
+}
+
+// newClient sets up a client with the session token and ledger the fetch and
+// submit commands share. The ledger is loaded for the client to keep the time
+// of its last request in.
+func newClient(sessionFile, baseURL, ledgerPath string) (*aoc.Client, error) {
+	if ledgerPath == "" {
+		var err error
+		if ledgerPath, err = aoc.DefaultLedgerPath(); err != nil {
+			return nil, err
+		}
+	}
+	ledger, err := aoc.LoadLedger(ledgerPath)
+	if err != nil {
+		return nil, err
+	}
+
+	session, err := aoc.LoadSession(sessionFile)
+	if err != nil {
+		return nil, err
+	}
+	client := aoc.NewClient(session)
+	if baseURL != "" {
+		client.BaseURL = strings.TrimRight(baseURL, "/")
+	}
+	client.Ledger = ledger
+	return client, nil
+}
@@ -0,0 +1,144 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+const usage = `usage: aoc <command> [flags]
+
+commands:
+  run    solve one or more days (aoc run -day 16 -part 2 -format json)
+  bench  time one or more days (aoc bench -n 20 -save before.json)
+  fetch  download a day's input to <day>/in.txt (aoc fetch -day 20)
+  submit solve a part and submit the answer (aoc submit -day 20 -part 1)
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	var err error
+	switch os.Args[1] {
+	case "run":
+		err = runCommand(os.Args[2:])
+	case "bench":
+		err = benchCommand(os.Args[2:])
+	case "fetch":
+		err = fetchCommand(os.Args[2:])
+	case "submit":
+		err = submitCommand(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "aoc: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func runCommand(args []string) error {
+	fs := flag.NewFlagSet("run", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to solve (0 solves every registered day)")
+	part := fs.Int("part", 0, "part to solve (0 solves both)")
+	input := fs.String("input", "", "puzzle input file (defaults to <day>/in.txt)")
+	format := fs.String("format", "text", "output format: text, json (one object per line) or tsv")
+	fs.Parse(args)
+
+	out, err := newFormatter(*format, os.Stdout)
+	if err != nil {
+		return err
+	}
+
+	days, err := selectDays(*day, *input, fs.Args())
+	if err != nil {
+		return err
+	}
+
+	for _, d := range days {
+		s, in, path, err := loadDay(d, *input, fs.Args())
+		if err != nil {
+			return err
+		}
+
+		for _, p := range selectParts(*part) {
+			start := time.Now()
+			answer, err := runner.Solve(s, p, in)
+			if err != nil {
+				return fmt.Errorf("day %d part %d: %w", d, p, io.WithFile(err, path))
+			}
+			err = out.Write(Record{
+				Day:      d,
+				Part:     p,
+				Input:    path,
+				Answer:   answer.String(),
+				Duration: time.Since(start),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// selectDays returns the days picked by -day, where 0 means all of them. A
+// custom input file or extra arguments only make sense for a single day.
+func selectDays(day int, input string, extra []string) ([]int, error) {
+	if day != 0 {
+		return []int{day}, nil
+	}
+	if input != "" || len(extra) > 0 {
+		return nil, fmt.Errorf("-input and extra arguments require -day")
+	}
+	return runner.Days(), nil
+}
+
+// selectParts returns the parts picked by -part, where 0 means both.
+func selectParts(part int) []int {
+	if part != 0 {
+		return []int{part}
+	}
+	return []int{1, 2}
+}
+
+// loadDay looks up a day's solver, passes it any extra arguments (an error if
+// it takes none) and reads its input, which defaults to <day>/in.txt.
+func loadDay(day int, input string, extra []string) (s runner.Solver, in, path string, err error) {
+	s, ok := runner.Get(day)
+	if !ok {
+		return nil, "", "", fmt.Errorf("no solver registered for day %d", day)
+	}
+
+	if len(extra) > 0 {
+		c, ok := s.(runner.Configurable)
+		if !ok {
+			return nil, "", "", fmt.Errorf("day %d takes no options", day)
+		}
+		if err := c.Configure(extra); err != nil {
+			return nil, "", "", fmt.Errorf("day %d: %w", day, err)
+		}
+	}
+
+	path = input
+	if path == "" {
+		path = filepath.Join(strconv.Itoa(day), "in.txt")
+	}
+	in, err = io.ReadInputFile(path)
+	if err != nil {
+		return nil, "", "", err
+	}
+	return s, in, path, nil
+}
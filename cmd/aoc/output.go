@@ -0,0 +1,61 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+)
+
+// Record is one solved part, as reported by aoc run.
+type Record struct {
+	Day      int           `json:"day"`
+	Part     int           `json:"part"`
+	Input    string        `json:"input"`
+	Answer   string        `json:"answer"`
+	Duration time.Duration `json:"duration_ns"`
+}
+
+// formatter writes records as they are produced, so long runs show progress.
+type formatter interface {
+	Write(r Record) error
+}
+
+func newFormatter(name string, w io.Writer) (formatter, error) {
+	switch name {
+	case "text":
+		return textFormatter{w}, nil
+	case "json":
+		return jsonFormatter{json.NewEncoder(w)}, nil
+	case "tsv":
+		if _, err := fmt.Fprintln(w, "day\tpart\tinput\tanswer\tduration_ns"); err != nil {
+			return nil, err
+		}
+		return tsvFormatter{w}, nil
+	}
+	return nil, fmt.Errorf("unknown format %q, want text, json or tsv", name)
+}
+
+// textFormatter prints the human-readable "day 1 part 2: 31" lines.
+type textFormatter struct{ w io.Writer }
+
+func (f textFormatter) Write(r Record) error {
+	_, err := fmt.Fprintf(f.w, "day %d part %d: %s\n", r.Day, r.Part, r.Answer)
+	return err
+}
+
+// jsonFormatter writes one JSON object per line.
+type jsonFormatter struct{ enc *json.Encoder }
+
+func (f jsonFormatter) Write(r Record) error {
+	return f.enc.Encode(r)
+}
+
+// tsvFormatter writes tab-separated rows below a header line. Answers never
+// contain tabs or newlines, so no quoting is needed.
+type tsvFormatter struct{ w io.Writer }
+
+func (f tsvFormatter) Write(r Record) error {
+	_, err := fmt.Fprintf(f.w, "%d\t%d\t%s\t%s\t%d\n", r.Day, r.Part, r.Input, r.Answer, r.Duration.Nanoseconds())
+	return err
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/aoc"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+func submitCommand(args []string) error {
+	fs := flag.NewFlagSet("submit", flag.ExitOnError)
+	day := fs.Int("day", 0, "day to submit")
+	part := fs.Int("part", 0, "part to submit")
+	input := fs.String("input", "", "puzzle input file (defaults to <day>/in.txt)")
+	answer := fs.String("answer", "", "submit this answer instead of solving the puzzle")
+	ledgerPath := fs.String("ledger", "", "file recording past guesses and the last request (defaults to <user config dir>/aoc/ledger.json)")
+	sessionFile := fs.String("session-file", "", "file holding the session token (defaults to <user config dir>/aoc/session; $"+aoc.SessionEnv+" wins)")
+	baseURL := fs.String("base-url", "", "site to submit to (defaults to $"+aoc.BaseURLEnv+" or "+aoc.DefaultBaseURL+")")
+	fs.Parse(args)
+
+	if *day == 0 || (*part != 1 && *part != 2) {
+		return fmt.Errorf("submit needs -day and -part 1 or 2")
+	}
+
+	if *answer == "" {
+		s, in, path, err := loadDay(*day, *input, fs.Args())
+		if err != nil {
+			return err
+		}
+		a, err := runner.Solve(s, *part, in)
+		if err != nil {
+			return fmt.Errorf("day %d part %d: %w", *day, *part, io.WithFile(err, path))
+		}
+		*answer = a.String()
+	}
+
+	client, err := newClient(*sessionFile, *baseURL, *ledgerPath)
+	if err != nil {
+		return err
+	}
+	ledger := client.Ledger
+
+	if err := ledger.Check(client.Year, *day, *part, *answer); err != nil {
+		return fmt.Errorf("not submitting day %d part %d: %w", *day, *part, err)
+	}
+
+	fmt.Printf("submitting day %d part %d: %s\n", *day, *part, *answer)
+	verdict, err := client.Submit(*day, *part, *answer)
+	if err != nil {
+		return err
+	}
+	if err := ledger.Record(client.Year, *day, *part, *answer, verdict.Outcome); err != nil {
+		return err
+	}
+
+	fmt.Println(verdict.Message)
+	switch {
+	case verdict.Outcome == aoc.Correct:
+		return nil
+	case verdict.Outcome == aoc.Unknown:
+		return fmt.Errorf("could not understand the response")
+	case verdict.Wait > 0:
+		return fmt.Errorf("%s, wait %s before the next guess", verdict.Outcome, verdict.Wait)
+	}
+	return fmt.Errorf("%s", verdict.Outcome)
+}
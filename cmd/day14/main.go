@@ -0,0 +1,84 @@
+// Command day14 renders the day 14 robots as images, to look for the
+// picture by eye.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	day14 "github.com/faideww/aoc-2024/14"
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/geom"
+)
+
+const usage = `usage: day14 <command> [flags]
+
+commands:
+  png  draw the robots after -step steps
+  gif  animate the robots from step -from to -to
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cmd := os.Args[1]
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	input := fs.String("input", "14/in.txt", "puzzle input file")
+	out := fs.String("o", "", "output file (defaults to robots.png or robots.gif)")
+	step := fs.Int("step", 0, "png: the step to draw")
+	from := fs.Int("from", 0, "gif: the first step to draw")
+	to := fs.Int("to", 100, "gif: the last step to draw")
+	size := geom.Vec2{}
+	fs.IntVar(&size.X, "width", 0, "arena width, or 0 to infer it from the robots")
+	fs.IntVar(&size.Y, "height", 0, "arena height, or 0 to infer it from the robots")
+	opts := day14.RenderOptions{Split: day14.Split{Cols: 2, Rows: 2}}
+	fs.IntVar(&opts.Scale, "scale", 4, "pixels per tile")
+	fs.BoolVar(&opts.Quadrants, "quadrants", false, "shade the rows and columns between the regions")
+	fs.Var(&opts.Split, "split", "`columns`x`rows` of regions -quadrants shows")
+	fs.IntVar(&opts.Delay, "delay", 10, "gif: time between frames in hundredths of a second")
+	fs.Parse(os.Args[2:])
+
+	if *out == "" {
+		*out = "robots." + cmd
+	}
+	if err := run(cmd, *input, *out, size, *step, *from, *to, opts); err != nil {
+		fmt.Fprintf(os.Stderr, "day14: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(cmd, input, out string, size geom.Vec2, step, from, to int, opts day14.RenderOptions) error {
+	in, err := io.ReadInputFile(input)
+	if err != nil {
+		return err
+	}
+	board, err := day14.ParseBoard(in, size)
+	if err != nil {
+		return io.WithFile(err, input)
+	}
+
+	var write func(f *os.File) error
+	switch cmd {
+	case "png":
+		write = func(f *os.File) error { return day14.WritePNG(f, board, step, opts) }
+	case "gif":
+		write = func(f *os.File) error { return day14.WriteGIF(f, board, from, to, opts) }
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	f, err := os.Create(out)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
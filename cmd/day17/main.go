@@ -0,0 +1,94 @@
+// Command day17 inspects day 17 programs: it can list them, trace a run or
+// step through one interactively.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/faideww/aoc-2024/17/cpu"
+	io "github.com/faideww/aoc-2024/lib"
+)
+
+const usage = `usage: day17 <command> [flags]
+
+commands:
+  dis    print a listing of the program
+  trace  run the program, logging the registers after every instruction
+  debug  step through the program with breakpoints (type help at the prompt)
+  asm    assemble the -input source file into the puzzle's input format
+  quine  find the smallest A (or with -all, every A) that makes the program print itself
+`
+
+func main() {
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cmd := os.Args[1]
+	fs := flag.NewFlagSet(cmd, flag.ExitOnError)
+	input := fs.String("input", "17/in.txt", "puzzle input file")
+	a := fs.Int("a", -1, "initial value of register A (defaults to the one in the input)")
+	maxSteps := fs.Int("max-steps", cpu.DefaultMaxSteps, "stop after this many instructions (0 for no limit)")
+	all := fs.Bool("all", false, "quine: list every solution, not just the smallest")
+	fs.Parse(os.Args[2:])
+
+	if err := run(cmd, *input, *a, *maxSteps, *all); err != nil {
+		fmt.Fprintf(os.Stderr, "day17: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(cmd, input string, a, maxSteps int, all bool) error {
+	in, err := io.ReadInputFile(input)
+	if err != nil {
+		return err
+	}
+	parse := cpu.Parse
+	if cmd == "asm" {
+		parse = cpu.Assemble
+	}
+	c, err := parse(in)
+	if err != nil {
+		return io.WithFile(err, input)
+	}
+	if a >= 0 {
+		c.A = a
+	}
+	c.MaxSteps = maxSteps
+
+	switch cmd {
+	case "asm":
+		fmt.Print(cpu.Format(c.Registers, c.Program))
+	case "dis":
+		fmt.Print(cpu.Disassemble(c.Program))
+	case "trace":
+		c.Trace = os.Stdout
+		output, err := c.Run()
+		if err != nil {
+			return err
+		}
+		fmt.Printf("halted after %d steps, output: %s\n", c.Steps, cpu.FormatOutput(output))
+	case "debug":
+		return cpu.NewDebugger(c).Run(os.Stdin, os.Stdout)
+	case "quine":
+		loop, err := cpu.AnalyzeLoop(c.Program)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("loop shifts A by %d bits per output, printing with %q\n", loop.Shift, loop.Out)
+		quines, err := cpu.FindQuines(c.Program, all, maxSteps)
+		if err != nil {
+			return err
+		}
+		for _, q := range quines {
+			fmt.Printf("A=%d (0o%o)\n", q, q)
+		}
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	return nil
+}
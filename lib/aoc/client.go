@@ -0,0 +1,215 @@
+// Package aoc talks to the Advent of Code website.
+package aoc
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBaseURL = "https://adventofcode.com"
+	Year           = 2024
+
+	// SessionEnv holds the value of the "session" cookie from a logged-in
+	// browser. It takes precedence over the session file.
+	SessionEnv = "AOC_SESSION"
+	// BaseURLEnv points the client at another server, eg. a local stub.
+	BaseURLEnv = "AOC_BASE_URL"
+)
+
+// ErrNoSession is returned by LoadSession when no token is configured.
+var ErrNoSession = errors.New("no session token: set " + SessionEnv + " or write it to " + sessionFileHint)
+
+const sessionFileHint = "<user config dir>/aoc/session"
+
+// Client fetches puzzle data, waiting at least MinInterval between requests so
+// we never hammer the site. If Ledger is set, the time of the last request is
+// kept in it, so the interval also holds between separate runs.
+//
+// A request the site turns away with 429 Too Many Requests is tried again up
+// to MaxRetries times, after whichever is longer of the Retry-After it gives
+// and RetryBackoff, which doubles with each retry.
+type Client struct {
+	BaseURL      string
+	Year         int
+	Session      string
+	MinInterval  time.Duration
+	MaxRetries   int
+	RetryBackoff time.Duration
+	HTTP         *http.Client
+	Ledger       *Ledger
+
+	mu   sync.Mutex
+	last time.Time
+	// sleep replaces time.Sleep in tests
+	sleep func(time.Duration)
+}
+
+// NewClient returns a client for this year's puzzles. The base URL comes from
+// $AOC_BASE_URL if set.
+func NewClient(session string) *Client {
+	baseURL := os.Getenv(BaseURLEnv)
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	return &Client{
+		BaseURL:      strings.TrimRight(baseURL, "/"),
+		Year:         Year,
+		Session:      session,
+		MinInterval:  5 * time.Second,
+		MaxRetries:   3,
+		RetryBackoff: 10 * time.Second,
+		HTTP:         &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// LoadSession reads the session token from $AOC_SESSION, falling back to the
+// given file, or the default session file if path is empty.
+func LoadSession(path string) (string, error) {
+	if s := strings.TrimSpace(os.Getenv(SessionEnv)); s != "" {
+		return s, nil
+	}
+
+	if path == "" {
+		dir, err := os.UserConfigDir()
+		if err != nil {
+			return "", ErrNoSession
+		}
+		path = filepath.Join(dir, "aoc", "session")
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", ErrNoSession
+	} else if err != nil {
+		return "", err
+	}
+
+	s := strings.TrimSpace(string(data))
+	if s == "" {
+		return "", fmt.Errorf("%s: empty session token", path)
+	}
+	return s, nil
+}
+
+// InputPath is where a day's input is cached, relative to the repo root.
+func InputPath(day int) string {
+	return filepath.Join(strconv.Itoa(day), "in.txt")
+}
+
+// Input downloads a day's puzzle input.
+func (c *Client) Input(day int) (string, error) {
+	return c.do(http.MethodGet, fmt.Sprintf("/%d/day/%d/input", c.Year, day), "")
+}
+
+// StatusError is returned for any response other than 200 OK.
+type StatusError struct {
+	StatusCode int
+	RetryAfter time.Duration
+	Body       string
+}
+
+func (e *StatusError) Error() string {
+	switch e.StatusCode {
+	case http.StatusBadRequest, http.StatusUnauthorized:
+		return "server rejected the session token (is it expired?)"
+	case http.StatusNotFound:
+		return "not found (is the puzzle unlocked yet?)"
+	case http.StatusTooManyRequests:
+		if e.RetryAfter > 0 {
+			return fmt.Sprintf("rate limited, retry in %s", e.RetryAfter)
+		}
+		return "rate limited, try again later"
+	}
+	return fmt.Sprintf("unexpected status %d: %s", e.StatusCode, e.Body)
+}
+
+// do sends a request with the session cookie and returns the response body,
+// retrying if the site says we are sending too many. A non-empty body is
+// sent as a form.
+func (c *Client) do(method, path, body string) (string, error) {
+	backoff := c.RetryBackoff
+	for retry := 0; ; retry++ {
+		data, err := c.send(method, path, body)
+		var se *StatusError
+		if retry >= c.MaxRetries || !errors.As(err, &se) || se.StatusCode != http.StatusTooManyRequests {
+			return data, err
+		}
+		c.pause(max(se.RetryAfter, backoff))
+		backoff *= 2
+	}
+}
+
+func (c *Client) send(method, path, body string) (string, error) {
+	if c.Session == "" {
+		return "", ErrNoSession
+	}
+
+	req, err := http.NewRequest(method, c.BaseURL+path, strings.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.AddCookie(&http.Cookie{Name: "session", Value: c.Session})
+	req.Header.Set("User-Agent", "github.com/faideww/aoc-2024")
+	if body != "" {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+
+	if err := c.wait(); err != nil {
+		return "", err
+	}
+	resp, err := c.HTTP.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		e := &StatusError{StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(data))}
+		if secs, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil {
+			e.RetryAfter = time.Duration(secs) * time.Second
+		}
+		return "", e
+	}
+	return string(data), nil
+}
+
+// wait blocks until MinInterval has passed since the previous request, by
+// this client or (if there is a ledger) any earlier one, then notes the time
+// of the request about to be sent.
+func (c *Client) wait() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	last := c.last
+	if c.Ledger != nil && c.Ledger.LastRequest.After(last) {
+		last = c.Ledger.LastRequest
+	}
+	if next := last.Add(c.MinInterval); time.Now().Before(next) {
+		c.pause(time.Until(next))
+	}
+	c.last = time.Now()
+	if c.Ledger != nil {
+		c.Ledger.LastRequest = c.last
+		return c.Ledger.Save()
+	}
+	return nil
+}
+
+func (c *Client) pause(d time.Duration) {
+	if c.sleep != nil {
+		c.sleep(d)
+	} else {
+		time.Sleep(d)
+	}
+}
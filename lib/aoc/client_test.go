@@ -0,0 +1,141 @@
+package aoc
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"testing"
+	"time"
+)
+
+// newStub serves handler and returns a client pointed at it, without any
+// rate limiting. The client records how long it would have slept instead of
+// sleeping.
+func newStub(t *testing.T, handler http.HandlerFunc) (*Client, *[]time.Duration) {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := NewClient("secret")
+	c.BaseURL = srv.URL
+	c.MinInterval = 0
+	slept := []time.Duration{}
+	c.sleep = func(d time.Duration) { slept = append(slept, d) }
+	return c, &slept
+}
+
+func TestInput(t *testing.T) {
+	c, _ := newStub(t, func(w http.ResponseWriter, r *http.Request) {
+		if cookie, err := r.Cookie("session"); err != nil || cookie.Value != "secret" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch r.URL.Path {
+		case "/2024/day/5/input":
+			w.Write([]byte("47|53\n"))
+		case "/2024/day/9/input":
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusTooManyRequests)
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	got, err := c.Input(5)
+	if err != nil || got != "47|53\n" {
+		t.Fatalf("Input(5) = %q, %v", got, err)
+	}
+
+	for day, want := range map[int]int{9: http.StatusTooManyRequests, 25: http.StatusNotFound} {
+		_, err := c.Input(day)
+		var se *StatusError
+		if !errors.As(err, &se) || se.StatusCode != want {
+			t.Errorf("Input(%d): got %v, want status %d", day, err, want)
+		}
+	}
+
+	c.Session = "expired"
+	if _, err := c.Input(5); err == nil {
+		t.Errorf("Input with a bad session succeeded")
+	}
+}
+
+func TestRetryTooManyRequests(t *testing.T) {
+	// the server turns away the first refusals requests, only the first of
+	// them saying when to come back
+	requests, refusals := 0, 2
+	c, slept := newStub(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests > refusals {
+			w.Write([]byte("input"))
+			return
+		}
+		if requests == 1 {
+			w.Header().Set("Retry-After", "30")
+		}
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	c.RetryBackoff = 10 * time.Second
+
+	got, err := c.Input(1)
+	if err != nil || got != "input" {
+		t.Fatalf("Input(1) = %q, %v", got, err)
+	}
+	// the first wait honours Retry-After, the second the doubled backoff
+	if want := []time.Duration{30 * time.Second, 20 * time.Second}; !slices.Equal(*slept, want) {
+		t.Errorf("slept %v, want %v", *slept, want)
+	}
+
+	// a server that never relents is given up on after MaxRetries
+	requests, refusals, *slept = 0, 100, nil
+	_, err = c.Input(1)
+	var se *StatusError
+	if !errors.As(err, &se) || se.StatusCode != http.StatusTooManyRequests {
+		t.Errorf("Input(1): got %v, want status 429", err)
+	}
+	if requests != c.MaxRetries+1 || len(*slept) != c.MaxRetries {
+		t.Errorf("made %d requests with %d waits, want %d and %d", requests, len(*slept), c.MaxRetries+1, c.MaxRetries)
+	}
+}
+
+func TestIntervalKeptInLedger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.json")
+	handler := func(w http.ResponseWriter, r *http.Request) { w.Write([]byte("input")) }
+
+	first, _ := newStub(t, handler)
+	ledger, err := LoadLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	first.Ledger = ledger
+	if _, err := first.Input(1); err != nil {
+		t.Fatal(err)
+	}
+
+	// a second client, as if from another run, must wait out the interval
+	second, slept := newStub(t, handler)
+	second.MinInterval = time.Hour
+	if second.Ledger, err = LoadLedger(path); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := second.Input(1); err != nil {
+		t.Fatal(err)
+	}
+	if len(*slept) != 1 || (*slept)[0] < 59*time.Minute {
+		t.Errorf("slept %v, want close to an hour", *slept)
+	}
+}
+
+func TestLoadSession(t *testing.T) {
+	t.Setenv(SessionEnv, "")
+	if _, err := LoadSession(t.TempDir() + "/missing"); !errors.Is(err, ErrNoSession) {
+		t.Errorf("missing file: got %v, want ErrNoSession", err)
+	}
+
+	t.Setenv(SessionEnv, " from-env\n")
+	if s, err := LoadSession(""); err != nil || s != "from-env" {
+		t.Errorf("LoadSession = %q, %v", s, err)
+	}
+}
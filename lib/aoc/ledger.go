@@ -0,0 +1,109 @@
+package aoc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+)
+
+// Guess is one answer we have submitted, and what the site said about it.
+type Guess struct {
+	Answer  string    `json:"answer"`
+	Outcome Outcome   `json:"outcome"`
+	Time    time.Time `json:"time"`
+}
+
+// Ledger remembers every checked guess so known-wrong answers are never sent
+// twice. It is stored as JSON, keyed by "year/day/part". It also keeps the
+// time of the last request a Client sent, so the rate limit holds between
+// runs.
+type Ledger struct {
+	path        string
+	Guesses     map[string][]Guess `json:"guesses"`
+	LastRequest time.Time          `json:"last_request"`
+}
+
+// DefaultLedgerPath returns <user config dir>/aoc/ledger.json.
+func DefaultLedgerPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "aoc", "ledger.json"), nil
+}
+
+// LoadLedger reads the ledger at path. A missing file is an empty ledger.
+func LoadLedger(path string) (*Ledger, error) {
+	l := &Ledger{path: path, Guesses: make(map[string][]Guess)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return l, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, l); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return l, nil
+}
+
+func ledgerKey(year, day, part int) string {
+	return fmt.Sprintf("%d/%d/%d", year, day, part)
+}
+
+// Check returns an error if answer is already known to be wrong, either
+// because it was guessed before or because it lies outside the bounds set by
+// earlier "too high" and "too low" guesses. It also refuses to submit a part
+// that is already solved.
+func (l *Ledger) Check(year, day, part int, answer string) error {
+	value, err := strconv.Atoi(answer)
+	numeric := err == nil
+
+	for _, g := range l.Guesses[ledgerKey(year, day, part)] {
+		if g.Outcome == Correct {
+			return fmt.Errorf("already solved, the answer was %s", g.Answer)
+		}
+		if g.Answer == answer {
+			return fmt.Errorf("%s was already guessed on %s: %s", answer, g.Time.Format(time.DateTime), g.Outcome)
+		}
+
+		bound, err := strconv.Atoi(g.Answer)
+		if !numeric || err != nil {
+			continue
+		}
+		if g.Outcome == TooHigh && value >= bound {
+			return fmt.Errorf("%s is not below %d, which was too high", answer, bound)
+		}
+		if g.Outcome == TooLow && value <= bound {
+			return fmt.Errorf("%s is not above %d, which was too low", answer, bound)
+		}
+	}
+	return nil
+}
+
+// Record adds a guess to the ledger and saves it. Guesses the site did not
+// check (eg. because we submitted too soon) are not recorded.
+func (l *Ledger) Record(year, day, part int, answer string, outcome Outcome) error {
+	if outcome != Correct && !outcome.IsWrong() {
+		return nil
+	}
+	key := ledgerKey(year, day, part)
+	l.Guesses[key] = append(l.Guesses[key], Guess{answer, outcome, time.Now()})
+	return l.Save()
+}
+
+// Save writes the ledger back to the file it was loaded from.
+func (l *Ledger) Save() error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(l.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(l.path, append(data, '\n'), 0644)
+}
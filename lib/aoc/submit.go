@@ -0,0 +1,129 @@
+package aoc
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Outcome is the site's verdict on a submitted answer.
+type Outcome int
+
+const (
+	Unknown Outcome = iota
+	Correct
+	Wrong
+	TooHigh
+	TooLow
+	// TooSoon means the answer was not checked because the previous guess was
+	// too recent.
+	TooSoon
+	// AlreadySolved means the part was solved before, so nothing was checked.
+	AlreadySolved
+)
+
+func (o Outcome) String() string {
+	switch o {
+	case Correct:
+		return "correct"
+	case Wrong:
+		return "wrong"
+	case TooHigh:
+		return "too high"
+	case TooLow:
+		return "too low"
+	case TooSoon:
+		return "too soon"
+	case AlreadySolved:
+		return "already solved"
+	}
+	return "unknown"
+}
+
+func (o Outcome) MarshalText() ([]byte, error) {
+	return []byte(o.String()), nil
+}
+
+func (o *Outcome) UnmarshalText(text []byte) error {
+	for candidate := Unknown; candidate <= AlreadySolved; candidate++ {
+		if candidate.String() == string(text) {
+			*o = candidate
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown outcome %q", text)
+}
+
+// IsWrong reports whether the answer was checked and rejected.
+func (o Outcome) IsWrong() bool {
+	return o == Wrong || o == TooHigh || o == TooLow
+}
+
+// Verdict is the parsed response to a submission.
+type Verdict struct {
+	Outcome Outcome
+	// Wait is how long the site asked us to wait before the next guess.
+	Wait time.Duration
+	// Message is the text of the response, without any markup.
+	Message string
+}
+
+// Submit posts an answer for one part of a day's puzzle.
+func (c *Client) Submit(day, part int, answer string) (Verdict, error) {
+	form := url.Values{"level": {strconv.Itoa(part)}, "answer": {answer}}
+	body, err := c.do(http.MethodPost, fmt.Sprintf("/%d/day/%d/answer", c.Year, day), form.Encode())
+	if err != nil {
+		return Verdict{}, err
+	}
+	return parseVerdict(body), nil
+}
+
+var (
+	articleRegex = regexp.MustCompile(`(?s)<article[^>]*>(.*?)</article>`)
+	tagRegex     = regexp.MustCompile(`<[^>]*>`)
+	spaceRegex   = regexp.MustCompile(`\s+`)
+	waitRegex    = regexp.MustCompile(`(?:(\d+)m )?(\d+)s left to wait`)
+	minuteRegex  = regexp.MustCompile(`wait (one|\d+) minutes?`)
+)
+
+func parseVerdict(body string) Verdict {
+	if m := articleRegex.FindStringSubmatch(body); m != nil {
+		body = m[1]
+	}
+	msg := tagRegex.ReplaceAllString(body, "")
+	msg = strings.TrimSpace(spaceRegex.ReplaceAllString(msg, " "))
+
+	v := Verdict{Message: msg}
+	switch {
+	case strings.Contains(msg, "That's the right answer"):
+		v.Outcome = Correct
+	case strings.Contains(msg, "That's not the right answer"):
+		v.Outcome = Wrong
+		if strings.Contains(msg, "too high") {
+			v.Outcome = TooHigh
+		} else if strings.Contains(msg, "too low") {
+			v.Outcome = TooLow
+		}
+	case strings.Contains(msg, "You gave an answer too recently"):
+		v.Outcome = TooSoon
+	case strings.Contains(msg, "You don't seem to be solving the right level"):
+		v.Outcome = AlreadySolved
+	}
+
+	if m := waitRegex.FindStringSubmatch(msg); m != nil {
+		minutes, _ := strconv.Atoi(m[1])
+		seconds, _ := strconv.Atoi(m[2])
+		v.Wait = time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second
+	} else if m := minuteRegex.FindStringSubmatch(msg); m != nil {
+		minutes := 1
+		if m[1] != "one" {
+			minutes, _ = strconv.Atoi(m[1])
+		}
+		v.Wait = time.Duration(minutes) * time.Minute
+	}
+	return v
+}
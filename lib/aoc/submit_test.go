@@ -0,0 +1,84 @@
+package aoc
+
+import (
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// Trimmed-down versions of the pages the site returns after a submission.
+var submitResponses = map[string]string{
+	"1": `<main><article><p>That's the right answer!  You are <span class="day-success">one gold star</span> closer.</p></article></main>`,
+	"2": `<main><article><p>That's not the right answer; your answer is too high.  If you're stuck, make sure you're using the full input data. Please wait one minute before trying again. <a href="/2024/day/5">[Return to Day 5]</a></p></article></main>`,
+	"3": `<main><article><p>That's not the right answer; your answer is too low.  Please wait 5 minutes before trying again.</p></article></main>`,
+	"4": `<main><article><p>You gave an answer too recently; you have to wait after submitting an answer before trying again.  You have 2m 41s left to wait. <a href="/2024/day/5">[Return to Day 5]</a></p></article></main>`,
+	"5": `<main><article><p>You don't seem to be solving the right level.  Did you already complete it?</p></article></main>`,
+	"6": `<main><article><p>That's not the right answer.  Please wait one minute before trying again.</p></article></main>`,
+}
+
+func TestSubmit(t *testing.T) {
+	c, _ := newStub(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/2024/day/5/answer" || r.FormValue("level") != "1" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(submitResponses[r.FormValue("answer")]))
+	})
+
+	tests := []struct {
+		answer  string
+		outcome Outcome
+		wait    time.Duration
+	}{
+		{"1", Correct, 0},
+		{"2", TooHigh, time.Minute},
+		{"3", TooLow, 5 * time.Minute},
+		{"4", TooSoon, 2*time.Minute + 41*time.Second},
+		{"5", AlreadySolved, 0},
+		{"6", Wrong, time.Minute},
+		{"7", Unknown, 0},
+	}
+	for _, tt := range tests {
+		v, err := c.Submit(5, 1, tt.answer)
+		if err != nil {
+			t.Fatalf("Submit(%s): %v", tt.answer, err)
+		}
+		if v.Outcome != tt.outcome || v.Wait != tt.wait {
+			t.Errorf("Submit(%s) = %s, wait %s; want %s, wait %s", tt.answer, v.Outcome, v.Wait, tt.outcome, tt.wait)
+		}
+	}
+}
+
+func TestLedger(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "ledger.json")
+	l, err := LoadLedger(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	l.Record(2024, 5, 1, "500", TooHigh)
+	l.Record(2024, 5, 1, "100", TooLow)
+	l.Record(2024, 5, 1, "250", Wrong)
+	l.Record(2024, 5, 1, "260", TooSoon) // never checked, so not recorded
+
+	// reload to make sure the ledger survives a round trip
+	if l, err = LoadLedger(path); err != nil {
+		t.Fatal(err)
+	}
+	for answer, ok := range map[string]bool{
+		"500": false, "600": false, "100": false, "50": false, "250": false,
+		"260": true, "101": true, "499": true, "abc": true,
+	} {
+		if err := l.Check(2024, 5, 1, answer); (err == nil) != ok {
+			t.Errorf("Check(%s) = %v, want ok %v", answer, err, ok)
+		}
+	}
+
+	if err := l.Check(2024, 5, 2, "600"); err != nil {
+		t.Errorf("bounds leaked into part 2: %v", err)
+	}
+	l.Record(2024, 5, 2, "42", Correct)
+	if err := l.Check(2024, 5, 2, "43"); err == nil {
+		t.Errorf("Check allowed a guess for a solved part")
+	}
+}
@@ -0,0 +1,154 @@
+package geom
+
+import "fmt"
+
+// Vec2 is a 2D integer vector, used both for positions and offsets. Like the
+// puzzle inputs, Y grows downward, so "up" is negative Y.
+type Vec2 struct {
+	X, Y int
+}
+
+func (v Vec2) Add(o Vec2) Vec2 { return Vec2{v.X + o.X, v.Y + o.Y} }
+
+func (v Vec2) Sub(o Vec2) Vec2 { return Vec2{v.X - o.X, v.Y - o.Y} }
+
+func (v Vec2) Scale(k int) Vec2 { return Vec2{v.X * k, v.Y * k} }
+
+func (v Vec2) Neg() Vec2 { return Vec2{-v.X, -v.Y} }
+
+// Wrap folds v into the box [0, size.X) x [0, size.Y), as if the edges of
+// the box were joined together. Unlike %, the result is never negative.
+func (v Vec2) Wrap(size Vec2) Vec2 {
+	return Vec2{Mod(v.X, size.X), Mod(v.Y, size.Y)}
+}
+
+// Manhattan returns the taxicab distance between v and o.
+func (v Vec2) Manhattan(o Vec2) int {
+	return abs(v.X-o.X) + abs(v.Y-o.Y)
+}
+
+// Chebyshev returns the number of king moves between v and o.
+func (v Vec2) Chebyshev(o Vec2) int {
+	return max(abs(v.X-o.X), abs(v.Y-o.Y))
+}
+
+// RotateRight turns v a quarter turn clockwise (as seen on screen).
+func (v Vec2) RotateRight() Vec2 { return Vec2{-v.Y, v.X} }
+
+// RotateLeft turns v a quarter turn anticlockwise (as seen on screen).
+func (v Vec2) RotateLeft() Vec2 { return Vec2{v.Y, -v.X} }
+
+// Rotate turns v by the given number of clockwise quarter turns. Negative
+// values turn anticlockwise.
+func (v Vec2) Rotate(quarterTurns int) Vec2 {
+	switch Mod(quarterTurns, 4) {
+	case 1:
+		return v.RotateRight()
+	case 2:
+		return v.Neg()
+	case 3:
+		return v.RotateLeft()
+	}
+	return v
+}
+
+func (v Vec2) String() string { return fmt.Sprintf("(%d,%d)", v.X, v.Y) }
+
+// Dir is one of the four compass directions, in clockwise order.
+type Dir int
+
+const (
+	North Dir = iota
+	East
+	South
+	West
+)
+
+// Dirs lists every direction in clockwise order, starting with North.
+var Dirs = [4]Dir{North, East, South, West}
+
+var dirVecs = [4]Vec2{{0, -1}, {1, 0}, {0, 1}, {-1, 0}}
+
+// Vec returns the unit step in direction d.
+func (d Dir) Vec() Vec2 { return dirVecs[d] }
+
+func (d Dir) TurnRight() Dir { return (d + 1) % 4 }
+
+func (d Dir) TurnLeft() Dir { return (d + 3) % 4 }
+
+func (d Dir) Reverse() Dir { return (d + 2) % 4 }
+
+// Arrow returns the ^ > v < character for d.
+func (d Dir) Arrow() rune { return rune("^>v<"[d]) }
+
+func (d Dir) String() string { return string("NESW"[d]) }
+
+// ParseArrow converts one of ^ > v < into a direction.
+func ParseArrow(r rune) (Dir, error) {
+	return parseDir(r, "^>v<")
+}
+
+// ParseCompass converts one of N E S W (or U R D L) into a direction.
+func ParseCompass(r rune) (Dir, error) {
+	if d, err := parseDir(r, "NESW"); err == nil {
+		return d, nil
+	}
+	return parseDir(r, "URDL")
+}
+
+func parseDir(r rune, chars string) (Dir, error) {
+	for i, c := range chars {
+		if c == r {
+			return Dir(i), nil
+		}
+	}
+	return 0, fmt.Errorf("%q is not a direction", r)
+}
+
+// Mod returns a modulo n in the range [0, n) for positive n. Unlike %, the
+// result is never negative.
+func Mod(a, n int) int {
+	return ((a % n) + n) % n
+}
+
+// FloorDiv divides a by b, rounding towards negative infinity rather than
+// towards zero as / does.
+func FloorDiv(a, b int) int {
+	q := a / b
+	if (a%b != 0) && ((a < 0) != (b < 0)) {
+		q--
+	}
+	return q
+}
+
+// CeilDiv divides a by b, rounding towards positive infinity.
+func CeilDiv(a, b int) int {
+	return -FloorDiv(-a, b)
+}
+
+// ExtendedGCD returns g = gcd(a, b) >= 0 along with x and y such that
+// a*x + b*y = g.
+func ExtendedGCD(a, b int) (g, x, y int) {
+	if b == 0 {
+		if a < 0 {
+			return -a, -1, 0
+		}
+		return a, 1, 0
+	}
+	g, x1, y1 := ExtendedGCD(b, a%b)
+	return g, y1, x1 - (a/b)*y1
+}
+
+// GCD returns the greatest common divisor of a and b, which is never
+// negative.
+func GCD(a, b int) int {
+	g, _, _ := ExtendedGCD(a, b)
+	return g
+}
+
+func abs(a int) int {
+	if a < 0 {
+		return -a
+	}
+	return a
+}
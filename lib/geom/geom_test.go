@@ -0,0 +1,40 @@
+package geom
+
+import "testing"
+
+func TestDivision(t *testing.T) {
+	tests := []struct {
+		a, b, floor, ceil, mod int
+	}{
+		{7, 2, 3, 4, 1},
+		{-7, 2, -4, -3, 1},
+		{7, -2, -4, -3, -1},
+		{-7, -2, 3, 4, -1},
+		{6, 3, 2, 2, 0},
+		{-6, 3, -2, -2, 0},
+		{0, 5, 0, 0, 0},
+	}
+	for _, tt := range tests {
+		if got := FloorDiv(tt.a, tt.b); got != tt.floor {
+			t.Errorf("FloorDiv(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.floor)
+		}
+		if got := CeilDiv(tt.a, tt.b); got != tt.ceil {
+			t.Errorf("CeilDiv(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.ceil)
+		}
+		if tt.b > 0 {
+			if got := Mod(tt.a, tt.b); got != tt.mod {
+				t.Errorf("Mod(%d, %d) = %d, want %d", tt.a, tt.b, got, tt.mod)
+			}
+		}
+	}
+}
+
+func TestExtendedGCD(t *testing.T) {
+	for _, tt := range [][3]int{{101, 103, 1}, {12, 18, 6}, {-12, 18, 6}, {12, -18, 6}, {0, 5, 5}, {5, 0, 5}, {-5, 0, 5}} {
+		a, b, want := tt[0], tt[1], tt[2]
+		g, x, y := ExtendedGCD(a, b)
+		if g != want || a*x+b*y != g {
+			t.Errorf("ExtendedGCD(%d, %d) = %d, %d, %d, want gcd %d with %d*x + %d*y = gcd", a, b, g, x, y, want, a, b)
+		}
+	}
+}
@@ -0,0 +1,215 @@
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"iter"
+	"strings"
+	"unicode/utf8"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/geom"
+)
+
+// Grid is a fixed-size 2D board stored row by row in a single slice. Cells are
+// addressed by geom.Vec2, with (0, 0) in the top left corner.
+type Grid[T any] struct {
+	Width, Height int
+	cells         []T
+}
+
+// New returns a width x height grid filled with T's zero value.
+func New[T any](width, height int) *Grid[T] {
+	return &Grid[T]{
+		Width:  width,
+		Height: height,
+		cells:  make([]T, width*height),
+	}
+}
+
+// Parse reads a rectangular block of text into a grid of runes.
+func Parse(input string) (*Grid[rune], error) {
+	return ParseFunc(input, func(_ geom.Vec2, r rune) (rune, error) { return r, nil })
+}
+
+// ParseFunc reads a rectangular block of ASCII text, converting each
+// character with f. Plain errors returned by f are reported as an
+// *io.ParseError pointing at the offending character, and an empty grid is an
+// error too.
+func ParseFunc[T any](input string, f func(p geom.Vec2, r rune) (T, error)) (*Grid[T], error) {
+	lines := io.Lines(input)
+	if lines[0].Text == "" {
+		return nil, &io.ParseError{Msg: "expected a grid, got empty input"}
+	}
+	g := New[T](len(lines[0].Text), len(lines))
+	for y, line := range lines {
+		// each byte is a cell, so a multi-byte character would take several
+		if i := strings.IndexFunc(line.Text, func(r rune) bool { return r >= utf8.RuneSelf }); i >= 0 {
+			r, _ := utf8.DecodeRuneInString(line.Text[i:])
+			return nil, line.Errorf(i+1, "unexpected non-ASCII character %q", r)
+		}
+		if len(line.Text) != g.Width {
+			return nil, line.Errorf(0, "expected %d cells, got %d", g.Width, len(line.Text))
+		}
+		for x := 0; x < len(line.Text); x++ {
+			v, err := f(geom.Vec2{X: x, Y: y}, rune(line.Text[x]))
+			if err != nil {
+				var pe *io.ParseError
+				if !errors.As(err, &pe) {
+					err = line.Errorf(x+1, "%v", err)
+				}
+				return nil, err
+			}
+			g.cells[y*g.Width+x] = v
+		}
+	}
+	return g, nil
+}
+
+// In reports whether p lies inside the grid.
+func (g *Grid[T]) In(p geom.Vec2) bool {
+	return p.X >= 0 && p.X < g.Width && p.Y >= 0 && p.Y < g.Height
+}
+
+// Get returns the value at p, which must be in bounds.
+func (g *Grid[T]) Get(p geom.Vec2) T {
+	return g.cells[p.Y*g.Width+p.X]
+}
+
+// Lookup returns the value at p, or false if p is out of bounds.
+func (g *Grid[T]) Lookup(p geom.Vec2) (T, bool) {
+	if !g.In(p) {
+		var zero T
+		return zero, false
+	}
+	return g.Get(p), true
+}
+
+// Set stores v at p, which must be in bounds.
+func (g *Grid[T]) Set(p geom.Vec2, v T) {
+	g.cells[p.Y*g.Width+p.X] = v
+}
+
+// Swap exchanges the values at p and q.
+func (g *Grid[T]) Swap(p, q geom.Vec2) {
+	i, j := p.Y*g.Width+p.X, q.Y*g.Width+q.X
+	g.cells[i], g.cells[j] = g.cells[j], g.cells[i]
+}
+
+// Fill sets every cell to v.
+func (g *Grid[T]) Fill(v T) {
+	for i := range g.cells {
+		g.cells[i] = v
+	}
+}
+
+// Clone returns a copy of the grid that shares no storage with the original.
+func (g *Grid[T]) Clone() *Grid[T] {
+	cells := make([]T, len(g.cells))
+	copy(cells, g.cells)
+	return &Grid[T]{Width: g.Width, Height: g.Height, cells: cells}
+}
+
+// All iterates over every cell in reading order.
+func (g *Grid[T]) All() iter.Seq2[geom.Vec2, T] {
+	return func(yield func(geom.Vec2, T) bool) {
+		for i, v := range g.cells {
+			if !yield(geom.Vec2{X: i % g.Width, Y: i / g.Width}, v) {
+				return
+			}
+		}
+	}
+}
+
+// Walk iterates from start in steps of step until it leaves the grid.
+func (g *Grid[T]) Walk(start, step geom.Vec2) iter.Seq2[geom.Vec2, T] {
+	return func(yield func(geom.Vec2, T) bool) {
+		for p := start; g.In(p); p = p.Add(step) {
+			if !yield(p, g.Get(p)) {
+				return
+			}
+		}
+	}
+}
+
+// Row iterates over row y from left to right.
+func (g *Grid[T]) Row(y int) iter.Seq2[geom.Vec2, T] {
+	return g.Walk(geom.Vec2{X: 0, Y: y}, geom.East.Vec())
+}
+
+// Column iterates over column x from top to bottom.
+func (g *Grid[T]) Column(x int) iter.Seq2[geom.Vec2, T] {
+	return g.Walk(geom.Vec2{X: x, Y: 0}, geom.South.Vec())
+}
+
+// Diagonal iterates down and to the right from start.
+func (g *Grid[T]) Diagonal(start geom.Vec2) iter.Seq2[geom.Vec2, T] {
+	return g.Walk(start, geom.Vec2{X: 1, Y: 1})
+}
+
+// AntiDiagonal iterates down and to the left from start.
+func (g *Grid[T]) AntiDiagonal(start geom.Vec2) iter.Seq2[geom.Vec2, T] {
+	return g.Walk(start, geom.Vec2{X: -1, Y: 1})
+}
+
+// Orthogonal offsets in clockwise order, starting with up.
+var Offsets4 = []geom.Vec2{geom.North.Vec(), geom.East.Vec(), geom.South.Vec(), geom.West.Vec()}
+
+// Orthogonal and diagonal offsets in clockwise order, starting with up.
+var Offsets8 = []geom.Vec2{{X: 0, Y: -1}, {X: 1, Y: -1}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: 1}, {X: -1, Y: 1}, {X: -1, Y: 0}, {X: -1, Y: -1}}
+
+// Neighbors4 iterates over the in-bounds cells directly above, right of,
+// below and left of p.
+func (g *Grid[T]) Neighbors4(p geom.Vec2) iter.Seq2[geom.Vec2, T] {
+	return g.neighbors(p, Offsets4)
+}
+
+// Neighbors8 is like Neighbors4 but includes the diagonals.
+func (g *Grid[T]) Neighbors8(p geom.Vec2) iter.Seq2[geom.Vec2, T] {
+	return g.neighbors(p, Offsets8)
+}
+
+func (g *Grid[T]) neighbors(p geom.Vec2, offsets []geom.Vec2) iter.Seq2[geom.Vec2, T] {
+	return func(yield func(geom.Vec2, T) bool) {
+		for _, d := range offsets {
+			n := p.Add(d)
+			if g.In(n) && !yield(n, g.Get(n)) {
+				return
+			}
+		}
+	}
+}
+
+// FindAll returns the position of every cell equal to v, in reading order.
+func FindAll[T comparable](g *Grid[T], v T) []geom.Vec2 {
+	found := []geom.Vec2{}
+	for p, cell := range g.All() {
+		if cell == v {
+			found = append(found, p)
+		}
+	}
+	return found
+}
+
+// Format renders the grid one row per line, using cell to draw each value.
+func (g *Grid[T]) Format(cell func(p geom.Vec2, v T) string) string {
+	var sb strings.Builder
+	for y := 0; y < g.Height; y++ {
+		for p, v := range g.Row(y) {
+			sb.WriteString(cell(p, v))
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// String renders the grid with runes drawn as characters and anything else
+// formatted with %v.
+func (g *Grid[T]) String() string {
+	return g.Format(func(_ geom.Vec2, v T) string {
+		if r, ok := any(v).(rune); ok {
+			return string(r)
+		}
+		return fmt.Sprint(v)
+	})
+}
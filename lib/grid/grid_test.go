@@ -0,0 +1,229 @@
+package grid
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/geom"
+)
+
+// sample is a 4x3 grid with every cell distinct.
+const sample = "abcd\nefgh\nijkl"
+
+func parseSample(t *testing.T) *Grid[rune] {
+	t.Helper()
+	g, err := Parse(sample)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// cells collects the values an iterator visits, in order.
+func cells(seq func(yield func(geom.Vec2, rune) bool)) string {
+	var sb strings.Builder
+	for _, r := range seq {
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+func TestParse(t *testing.T) {
+	g := parseSample(t)
+	if g.Width != 4 || g.Height != 3 {
+		t.Fatalf("size = %dx%d, want 4x3", g.Width, g.Height)
+	}
+	if got := g.Get(geom.Vec2{X: 2, Y: 1}); got != 'g' {
+		t.Errorf("Get(2,1) = %q, want 'g'", got)
+	}
+	if got := cells(g.All()); got != "abcdefghijkl" {
+		t.Errorf("All = %q, want reading order", got)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := []struct {
+		name, input string
+		line, col   int
+		want        string
+	}{
+		{"empty", "", 0, 0, "empty input"},
+		{"blank", "\n\n  \n", 0, 0, "empty input"},
+		{"short row", "abc\nab\nabc", 2, 0, "expected 3 cells, got 2"},
+		{"long row", "abc\nabc\nabcd", 3, 0, "expected 3 cells, got 4"},
+		{"non-ASCII", "abc\naé", 2, 2, "non-ASCII character 'é'"},
+		{"non-ASCII of equal length", "abc\néa", 2, 1, "non-ASCII character 'é'"},
+	}
+	for _, tt := range tests {
+		_, err := Parse(tt.input)
+		var pe *io.ParseError
+		if !errors.As(err, &pe) {
+			t.Errorf("%s: got %v, want a ParseError", tt.name, err)
+			continue
+		}
+		if pe.Line != tt.line || pe.Col != tt.col || !strings.Contains(pe.Msg, tt.want) {
+			t.Errorf("%s: got %v, want %d:%d: ...%s", tt.name, err, tt.line, tt.col, tt.want)
+		}
+	}
+}
+
+func TestParseFuncErrors(t *testing.T) {
+	digit := func(_ geom.Vec2, r rune) (int, error) {
+		if r < '0' || r > '9' {
+			return 0, errors.New("not a digit")
+		}
+		return int(r - '0'), nil
+	}
+	g, err := ParseFunc("012\n345", digit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := g.Get(geom.Vec2{X: 1, Y: 1}); got != 4 {
+		t.Errorf("Get(1,1) = %d, want 4", got)
+	}
+
+	// plain errors from f are pointed at the offending character
+	_, err = ParseFunc("012\n3x5", digit)
+	var pe *io.ParseError
+	if !errors.As(err, &pe) || pe.Line != 2 || pe.Col != 2 || pe.Msg != "not a digit" {
+		t.Errorf("got %v, want 2:2: not a digit", err)
+	}
+}
+
+func TestLookup(t *testing.T) {
+	g := parseSample(t)
+	tests := []struct {
+		p    geom.Vec2
+		want rune
+		in   bool
+	}{
+		{geom.Vec2{X: 0, Y: 0}, 'a', true},
+		{geom.Vec2{X: 3, Y: 0}, 'd', true},
+		{geom.Vec2{X: 0, Y: 2}, 'i', true},
+		{geom.Vec2{X: 3, Y: 2}, 'l', true},
+		{geom.Vec2{X: -1, Y: 0}, 0, false},
+		{geom.Vec2{X: 0, Y: -1}, 0, false},
+		{geom.Vec2{X: 4, Y: 0}, 0, false},
+		{geom.Vec2{X: 0, Y: 3}, 0, false},
+		// in range of the backing slice, but not of the grid
+		{geom.Vec2{X: 4, Y: 1}, 0, false},
+	}
+	for _, tt := range tests {
+		if in := g.In(tt.p); in != tt.in {
+			t.Errorf("In(%v) = %t, want %t", tt.p, in, tt.in)
+		}
+		if got, ok := g.Lookup(tt.p); got != tt.want || ok != tt.in {
+			t.Errorf("Lookup(%v) = %q, %t, want %q, %t", tt.p, got, ok, tt.want, tt.in)
+		}
+	}
+}
+
+func TestNeighbors(t *testing.T) {
+	g := parseSample(t)
+	tests := []struct {
+		name  string
+		p     geom.Vec2
+		want4 string
+		want8 string
+	}{
+		{"top left", geom.Vec2{X: 0, Y: 0}, "be", "bfe"},
+		{"top right", geom.Vec2{X: 3, Y: 0}, "hc", "hgc"},
+		{"bottom left", geom.Vec2{X: 0, Y: 2}, "ej", "efj"},
+		{"bottom right", geom.Vec2{X: 3, Y: 2}, "hk", "hkg"},
+		{"middle", geom.Vec2{X: 1, Y: 1}, "bgje", "bcgkjiea"},
+	}
+	for _, tt := range tests {
+		if got := cells(g.Neighbors4(tt.p)); got != tt.want4 {
+			t.Errorf("%s: Neighbors4 = %q, want %q", tt.name, got, tt.want4)
+		}
+		if got := cells(g.Neighbors8(tt.p)); got != tt.want8 {
+			t.Errorf("%s: Neighbors8 = %q, want %q", tt.name, got, tt.want8)
+		}
+	}
+}
+
+func TestWalk(t *testing.T) {
+	g := parseSample(t)
+	tests := []struct {
+		name string
+		seq  func(yield func(geom.Vec2, rune) bool)
+		want string
+	}{
+		{"row", g.Row(1), "efgh"},
+		{"last row", g.Row(2), "ijkl"},
+		{"row outside", g.Row(3), ""},
+		{"column", g.Column(3), "dhl"},
+		{"column outside", g.Column(-1), ""},
+		{"diagonal", g.Diagonal(geom.Vec2{X: 0, Y: 0}), "afk"},
+		{"diagonal off the side", g.Diagonal(geom.Vec2{X: 2, Y: 0}), "ch"},
+		{"anti-diagonal", g.AntiDiagonal(geom.Vec2{X: 3, Y: 0}), "dgj"},
+		{"walk west", g.Walk(geom.Vec2{X: 3, Y: 2}, geom.West.Vec()), "lkji"},
+		{"walk from outside", g.Walk(geom.Vec2{X: 9, Y: 9}, geom.North.Vec()), ""},
+	}
+	for _, tt := range tests {
+		if got := cells(tt.seq); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// stopping early ends the walk
+	for p := range g.Row(0) {
+		if p.X > 0 {
+			t.Fatalf("Row kept going after break, at %v", p)
+		}
+		break
+	}
+}
+
+func TestFindAll(t *testing.T) {
+	g, err := Parse("#.#\n...\n#.#")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []geom.Vec2{{X: 0, Y: 0}, {X: 2, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 2}}
+	if got := FindAll(g, '#'); !slices.Equal(got, want) {
+		t.Errorf("FindAll(#) = %v, want %v", got, want)
+	}
+	if got := FindAll(g, 'x'); len(got) != 0 {
+		t.Errorf("FindAll(x) = %v, want none", got)
+	}
+}
+
+func TestClone(t *testing.T) {
+	g := parseSample(t)
+	c := g.Clone()
+	c.Set(geom.Vec2{X: 0, Y: 0}, 'z')
+	c.Swap(geom.Vec2{X: 1, Y: 0}, geom.Vec2{X: 2, Y: 0})
+	if got := g.String(); got != sample+"\n" {
+		t.Errorf("changing the clone changed the original:\n%s", got)
+	}
+	if got := c.String(); got != "zcbd\nefgh\nijkl\n" {
+		t.Errorf("clone = %q", got)
+	}
+}
+
+func TestString(t *testing.T) {
+	if got := parseSample(t).String(); got != sample+"\n" {
+		t.Errorf("String = %q, want %q", got, sample+"\n")
+	}
+
+	ints := New[int](3, 2)
+	ints.Fill(7)
+	ints.Set(geom.Vec2{X: 1, Y: 1}, 0)
+	if got := ints.String(); got != "777\n707\n" {
+		t.Errorf("String = %q, want %q", got, "777\n707\n")
+	}
+
+	marked := parseSample(t).Format(func(p geom.Vec2, r rune) string {
+		if p.X == p.Y {
+			return "*"
+		}
+		return string(r)
+	})
+	if want := "*bcd\ne*gh\nij*l\n"; marked != want {
+		t.Errorf("Format = %q, want %q", marked, want)
+	}
+}
@@ -0,0 +1,245 @@
+package io
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ParseError reports malformed puzzle input. Line and Col are 1-based; Col is
+// 0 when the problem concerns the whole line. File is usually left blank by
+// the parser and filled in later with WithFile by whoever opened the input.
+type ParseError struct {
+	File string
+	Line int
+	Col  int
+	Msg  string
+	Err  error
+}
+
+func (e *ParseError) Error() string {
+	var sb strings.Builder
+	if e.File != "" {
+		sb.WriteString(e.File)
+		sb.WriteString(":")
+	}
+	if e.Line > 0 {
+		fmt.Fprintf(&sb, "%d:", e.Line)
+		if e.Col > 0 {
+			fmt.Fprintf(&sb, "%d:", e.Col)
+		}
+	}
+	if sb.Len() > 0 {
+		sb.WriteString(" ")
+	}
+	sb.WriteString(e.Msg)
+	return sb.String()
+}
+
+func (e *ParseError) Unwrap() error { return e.Err }
+
+// WithFile records the input file name on err if it is (or wraps) a
+// *ParseError, and returns err.
+func WithFile(err error, file string) error {
+	var pe *ParseError
+	if errors.As(err, &pe) && pe.File == "" {
+		pe.File = file
+	}
+	return err
+}
+
+// Line is a single line of puzzle input, numbered from 1.
+type Line struct {
+	Num  int
+	Text string
+}
+
+// Lines splits input into numbered lines. Like TrimAndSplit, surrounding
+// whitespace is dropped, but line numbers still count any leading blank lines.
+func Lines(input string) []Line {
+	input = strings.ReplaceAll(input, "\r\n", "\n")
+	trimmed := strings.TrimLeftFunc(input, unicode.IsSpace)
+	first := 1 + strings.Count(input[:len(input)-len(trimmed)], "\n")
+
+	texts := strings.Split(strings.TrimRightFunc(trimmed, unicode.IsSpace), "\n")
+	lines := make([]Line, len(texts))
+	for i, text := range texts {
+		lines[i] = Line{Num: first + i, Text: text}
+	}
+	return lines
+}
+
+// Sections splits input into groups of lines separated by blank lines.
+func Sections(input string) [][]Line {
+	sections := [][]Line{}
+	current := []Line{}
+	for _, line := range Lines(input) {
+		if strings.TrimSpace(line.Text) == "" {
+			if len(current) > 0 {
+				sections = append(sections, current)
+				current = []Line{}
+			}
+			continue
+		}
+		current = append(current, line)
+	}
+	if len(current) > 0 {
+		sections = append(sections, current)
+	}
+	return sections
+}
+
+// Errorf returns a *ParseError pointing at the given column of the line.
+func (l Line) Errorf(col int, format string, args ...any) error {
+	return &ParseError{Line: l.Num, Col: col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Field returns the text between byte offsets start and end of the line.
+func (l Line) Field(start, end int) Field {
+	return Field{Text: l.Text[start:end], Line: l.Num, Col: start + 1}
+}
+
+// Fields splits the line around runs of whitespace, like strings.Fields.
+func (l Line) Fields() []Field {
+	fields := []Field{}
+	start := -1
+	for i, r := range l.Text {
+		if unicode.IsSpace(r) {
+			if start >= 0 {
+				fields = append(fields, l.Field(start, i))
+				start = -1
+			}
+		} else if start < 0 {
+			start = i
+		}
+	}
+	if start >= 0 {
+		fields = append(fields, l.Field(start, len(l.Text)))
+	}
+	return fields
+}
+
+// Split slices the line around each instance of sep, like strings.Split.
+func (l Line) Split(sep string) []Field {
+	fields := []Field{}
+	start := 0
+	for {
+		i := strings.Index(l.Text[start:], sep)
+		if i < 0 {
+			break
+		}
+		fields = append(fields, l.Field(start, start+i))
+		start += i + len(sep)
+	}
+	return append(fields, l.Field(start, len(l.Text)))
+}
+
+// Match applies re to the line and returns its capture groups (without the
+// full match). An error is returned if the line does not match.
+func (l Line) Match(re *regexp.Regexp) ([]Field, error) {
+	loc := re.FindStringSubmatchIndex(l.Text)
+	if loc == nil {
+		return nil, l.Errorf(0, "expected line matching %q, got %q", re.String(), l.Text)
+	}
+	return l.groups(loc), nil
+}
+
+// MatchAll returns the capture groups of every match of re in the line.
+func (l Line) MatchAll(re *regexp.Regexp) [][]Field {
+	matches := [][]Field{}
+	for _, loc := range re.FindAllStringSubmatchIndex(l.Text, -1) {
+		matches = append(matches, l.groups(loc))
+	}
+	return matches
+}
+
+// groups turns a submatch index slice into fields, skipping the full match.
+// Groups that did not participate in the match are returned empty.
+func (l Line) groups(loc []int) []Field {
+	groups := make([]Field, 0, len(loc)/2-1)
+	for i := 2; i < len(loc); i += 2 {
+		if loc[i] < 0 {
+			groups = append(groups, Field{Line: l.Num})
+			continue
+		}
+		groups = append(groups, l.Field(loc[i], loc[i+1]))
+	}
+	return groups
+}
+
+// Ints parses every whitespace-separated field of the line as an integer.
+func (l Line) Ints() ([]int, error) {
+	return Ints(l.Fields())
+}
+
+// Digits parses every character of the line as a single decimal digit.
+func (l Line) Digits() ([]int, error) {
+	digits := make([]int, len(l.Text))
+	for i := 0; i < len(l.Text); i++ {
+		if l.Text[i] < '0' || l.Text[i] > '9' {
+			return nil, l.Errorf(i+1, "expected digit, got %q", l.Text[i])
+		}
+		digits[i] = int(l.Text[i] - '0')
+	}
+	return digits, nil
+}
+
+// Field is a piece of a Line, remembering where it came from so that errors
+// can point at it.
+type Field struct {
+	Text string
+	Line int
+	Col  int
+}
+
+// Errorf returns a *ParseError pointing at the start of the field.
+func (f Field) Errorf(format string, args ...any) error {
+	return &ParseError{Line: f.Line, Col: f.Col, Msg: fmt.Sprintf(format, args...)}
+}
+
+// Fields splits the field around runs of whitespace, like strings.Fields.
+func (f Field) Fields() []Field {
+	return f.shift(Line{Num: f.Line, Text: f.Text}.Fields())
+}
+
+// Split slices the field around each instance of sep, like strings.Split.
+func (f Field) Split(sep string) []Field {
+	return f.shift(Line{Num: f.Line, Text: f.Text}.Split(sep))
+}
+
+// shift moves sub-fields found within f to their columns in the whole line.
+func (f Field) shift(fields []Field) []Field {
+	for i := range fields {
+		fields[i].Col += f.Col - 1
+	}
+	return fields
+}
+
+// Int parses the field as a base 10 integer.
+func (f Field) Int() (int, error) {
+	n, err := strconv.Atoi(f.Text)
+	if err != nil {
+		reason := "invalid integer"
+		if errors.Is(err, strconv.ErrRange) {
+			reason = "integer out of range"
+		}
+		return 0, &ParseError{Line: f.Line, Col: f.Col, Msg: fmt.Sprintf("%s %q", reason, f.Text), Err: err}
+	}
+	return n, nil
+}
+
+// Ints parses each field as an integer, stopping at the first error.
+func Ints(fields []Field) ([]int, error) {
+	values := make([]int, len(fields))
+	for i, f := range fields {
+		n, err := f.Int()
+		if err != nil {
+			return nil, err
+		}
+		values[i] = n
+	}
+	return values, nil
+}
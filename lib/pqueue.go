@@ -0,0 +1,109 @@
+package io
+
+// PQItem is a value held in a PriorityQueue. Index is the item's current
+// position in the heap, or -1 once it has been popped; keep the pointer
+// returned by Push to change its priority later with Update.
+type PQItem[T any] struct {
+	Value    T
+	Priority int
+	Index    int
+}
+
+// PriorityQueue is a binary heap ordered by a pluggable comparator. Less
+// reports whether a should be popped before b.
+type PriorityQueue[T any] struct {
+	items []*PQItem[T]
+	less  func(a, b *PQItem[T]) bool
+}
+
+// NewPriorityQueue returns an empty queue ordered by less.
+func NewPriorityQueue[T any](less func(a, b *PQItem[T]) bool) *PriorityQueue[T] {
+	return &PriorityQueue[T]{less: less}
+}
+
+// NewMinQueue returns an empty queue that pops the lowest priority first.
+func NewMinQueue[T any]() *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b *PQItem[T]) bool { return a.Priority < b.Priority })
+}
+
+// NewMaxQueue returns an empty queue that pops the highest priority first.
+func NewMaxQueue[T any]() *PriorityQueue[T] {
+	return NewPriorityQueue(func(a, b *PQItem[T]) bool { return a.Priority > b.Priority })
+}
+
+func (pq *PriorityQueue[T]) Len() int { return len(pq.items) }
+
+// Push adds value to the queue and returns its item, which can be passed to
+// Update while it is still queued.
+func (pq *PriorityQueue[T]) Push(value T, priority int) *PQItem[T] {
+	item := &PQItem[T]{Value: value, Priority: priority, Index: len(pq.items)}
+	pq.items = append(pq.items, item)
+	pq.up(item.Index)
+	return item
+}
+
+// Pop removes the first item in the queue and returns its value and
+// priority. The queue must not be empty.
+func (pq *PriorityQueue[T]) Pop() (T, int) {
+	n := len(pq.items) - 1
+	pq.swap(0, n)
+	item := pq.items[n]
+	pq.items[n] = nil // don't stop the GC from reclaiming the item eventually
+	pq.items = pq.items[:n]
+	pq.down(0)
+
+	item.Index = -1 // for safety
+	return item.Value, item.Priority
+}
+
+// Peek returns the value and priority Pop would return, without removing it.
+func (pq *PriorityQueue[T]) Peek() (T, int) {
+	return pq.items[0].Value, pq.items[0].Priority
+}
+
+// Update changes the priority of an item that is still in the queue and
+// restores the heap order, eg. to decrease a node's key in Dijkstra.
+func (pq *PriorityQueue[T]) Update(item *PQItem[T], priority int) {
+	item.Priority = priority
+	if !pq.down(item.Index) {
+		pq.up(item.Index)
+	}
+}
+
+func (pq *PriorityQueue[T]) swap(i, j int) {
+	pq.items[i], pq.items[j] = pq.items[j], pq.items[i]
+	pq.items[i].Index = i
+	pq.items[j].Index = j
+}
+
+func (pq *PriorityQueue[T]) up(i int) {
+	for i > 0 {
+		parent := (i - 1) / 2
+		if !pq.less(pq.items[i], pq.items[parent]) {
+			break
+		}
+		pq.swap(i, parent)
+		i = parent
+	}
+}
+
+// down sifts the item at i towards the leaves and reports whether it moved.
+func (pq *PriorityQueue[T]) down(i int) bool {
+	start := i
+	n := len(pq.items)
+	for {
+		first := 2*i + 1
+		if first >= n {
+			break
+		}
+		if right := first + 1; right < n && pq.less(pq.items[right], pq.items[first]) {
+			first = right
+		}
+		if !pq.less(pq.items[first], pq.items[i]) {
+			break
+		}
+		pq.swap(i, first)
+		i = first
+	}
+	return i > start
+}
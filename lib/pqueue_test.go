@@ -0,0 +1,33 @@
+package io
+
+import "testing"
+
+func TestPriorityQueue(t *testing.T) {
+	pq := NewMinQueue[string]()
+	pq.Push("c", 3)
+	b := pq.Push("b", 5)
+	pq.Push("a", 1)
+	pq.Push("d", 4)
+	pq.Update(b, 2) // decrease-key
+
+	want := []string{"a", "b", "c", "d"}
+	for i, w := range want {
+		if got, _ := pq.Peek(); got != w {
+			t.Fatalf("Peek %d: got %q, want %q", i, got, w)
+		}
+		if got, _ := pq.Pop(); got != w {
+			t.Fatalf("Pop %d: got %q, want %q", i, got, w)
+		}
+	}
+	if pq.Len() != 0 || b.Index != -1 {
+		t.Fatalf("queue not drained: len %d, popped index %d", pq.Len(), b.Index)
+	}
+
+	largest := NewMaxQueue[int]()
+	for _, p := range []int{2, 9, 4} {
+		largest.Push(p, p)
+	}
+	if got, prio := largest.Pop(); got != 9 || prio != 9 {
+		t.Fatalf("max queue popped %d (%d), want 9", got, prio)
+	}
+}
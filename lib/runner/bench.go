@@ -0,0 +1,61 @@
+package runner
+
+import (
+	"fmt"
+	"runtime"
+	"slices"
+	"time"
+)
+
+// Timing summarises repeated runs of one part of a day's puzzle. Durations
+// are stored in nanoseconds so results survive a round trip through JSON.
+type Timing struct {
+	Day    int           `json:"day"`
+	Part   int           `json:"part"`
+	Runs   int           `json:"runs"`
+	Min    time.Duration `json:"min_ns"`
+	Median time.Duration `json:"median_ns"`
+	Mean   time.Duration `json:"mean_ns"`
+	// Allocs and Bytes are the heap allocations made by a single run.
+	Allocs uint64 `json:"allocs"`
+	Bytes  uint64 `json:"bytes"`
+}
+
+// Benchmark solves one part runs times and records how long each run took and
+// how much it allocated. The input is parsed anew on every run, as it is when
+// solving normally.
+func Benchmark(s Solver, day, part int, input string, runs int) (Timing, error) {
+	if runs < 1 {
+		return Timing{}, fmt.Errorf("need at least one run, got %d", runs)
+	}
+
+	durations := make([]time.Duration, runs)
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	for i := range durations {
+		start := time.Now()
+		if _, err := Solve(s, part, input); err != nil {
+			return Timing{}, err
+		}
+		durations[i] = time.Since(start)
+	}
+	runtime.ReadMemStats(&after)
+
+	var total time.Duration
+	for _, d := range durations {
+		total += d
+	}
+	slices.Sort(durations)
+
+	return Timing{
+		Day:    day,
+		Part:   part,
+		Runs:   runs,
+		Min:    durations[0],
+		Median: durations[runs/2],
+		Mean:   total / time.Duration(runs),
+		Allocs: (after.Mallocs - before.Mallocs) / uint64(runs),
+		Bytes:  (after.TotalAlloc - before.TotalAlloc) / uint64(runs),
+	}, nil
+}
@@ -0,0 +1,73 @@
+package runner
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// Answer is the result of solving one part of a day's puzzle.
+type Answer interface {
+	String() string
+}
+
+// Int is a numeric answer, which is what most puzzles ask for.
+type Int int
+
+func (a Int) String() string { return strconv.Itoa(int(a)) }
+
+// Text is an answer that isn't a single number (eg. day 17's output list).
+type Text string
+
+func (a Text) String() string { return string(a) }
+
+// Solver is implemented by each day. Both parts receive the full,
+// whitespace-trimmed puzzle input.
+type Solver interface {
+	Part1(input string) (Answer, error)
+	Part2(input string) (Answer, error)
+}
+
+// Configurable is implemented by solvers that accept extra arguments on top
+// of the puzzle input (eg. day 18's byte count and arena size).
+type Configurable interface {
+	Configure(args []string) error
+}
+
+var solvers = make(map[int]Solver)
+
+// Register makes a day's solver available to the runner. It is meant to be
+// called from the day package's init function.
+func Register(day int, s Solver) {
+	if _, ok := solvers[day]; ok {
+		panic(fmt.Sprintf("runner: day %d registered twice", day))
+	}
+	solvers[day] = s
+}
+
+// Get returns the solver registered for a day.
+func Get(day int) (Solver, bool) {
+	s, ok := solvers[day]
+	return s, ok
+}
+
+// Days returns every registered day in ascending order.
+func Days() []int {
+	days := make([]int, 0, len(solvers))
+	for day := range solvers {
+		days = append(days, day)
+	}
+	slices.Sort(days)
+	return days
+}
+
+// Solve runs a single part (1 or 2) of a solver against the given input.
+func Solve(s Solver, part int, input string) (Answer, error) {
+	switch part {
+	case 1:
+		return s.Part1(input)
+	case 2:
+		return s.Part2(input)
+	}
+	return nil, fmt.Errorf("invalid part %d", part)
+}
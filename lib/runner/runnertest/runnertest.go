@@ -0,0 +1,57 @@
+// Package runnertest checks a day's solver against known answers.
+package runnertest
+
+import (
+	"testing"
+
+	io "github.com/faideww/aoc-2024/lib"
+	"github.com/faideww/aoc-2024/lib/runner"
+)
+
+// Case lists the expected answers for one input file, relative to the day's
+// directory. An empty answer skips that part, eg. where the puzzle text gives
+// no sample answer.
+type Case struct {
+	Input        string
+	Args         []string
+	Part1, Part2 string
+}
+
+// Run solves both parts of every case and reports any answer that differs
+// from the expected one. Each case gets a fresh solver from newSolver, so
+// that Args, if set, are passed to a runner.Configurable solver for that case
+// alone.
+func Run[S runner.Solver](t *testing.T, newSolver func() S, cases []Case) {
+	t.Helper()
+	for _, c := range cases {
+		t.Run(c.Input, func(t *testing.T) {
+			var s runner.Solver = newSolver()
+			if c.Args != nil {
+				cfg, ok := s.(runner.Configurable)
+				if !ok {
+					t.Fatalf("solver takes no arguments, got %q", c.Args)
+				}
+				if err := cfg.Configure(c.Args); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			input, err := io.ReadInputFile(c.Input)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for part, want := range []string{1: c.Part1, 2: c.Part2} {
+				if want == "" {
+					continue
+				}
+				got, err := runner.Solve(s, part, input)
+				if err != nil {
+					t.Errorf("part %d: %v", part, io.WithFile(err, c.Input))
+				} else if got.String() != want {
+					t.Errorf("part %d: got %s, want %s", part, got, want)
+				}
+			}
+		})
+	}
+}
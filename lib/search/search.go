@@ -0,0 +1,292 @@
+package search
+
+import (
+	"math/big"
+	"slices"
+
+	io "github.com/faideww/aoc-2024/lib"
+)
+
+// Edge is a weighted step from one node to a neighbouring node.
+type Edge[N comparable] struct {
+	To   N
+	Cost int
+}
+
+// Result describes the cheapest route found from the start to a goal node.
+type Result[N comparable] struct {
+	Start N
+	Goal  N
+	Cost  int
+
+	cameFrom map[N]N
+}
+
+// Path returns every node on the route, from the start to the goal inclusive.
+func (r Result[N]) Path() []N {
+	path := []N{r.Goal}
+	for current := r.Goal; current != r.Start; {
+		current = r.cameFrom[current]
+		path = append(path, current)
+	}
+
+	for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+		path[i], path[j] = path[j], path[i]
+	}
+	return path
+}
+
+// BFS finds the route to a goal with the fewest steps, for graphs where every
+// edge costs the same.
+func BFS[N comparable](start N, neighbors func(N) []N, isGoal func(N) bool) (Result[N], bool) {
+	frontier := []N{start}
+	cameFrom := map[N]N{}
+	steps := map[N]int{start: 0}
+
+	for len(frontier) > 0 {
+		current := frontier[0]
+		frontier = frontier[1:]
+
+		if isGoal(current) {
+			return Result[N]{start, current, steps[current], cameFrom}, true
+		}
+
+		for _, next := range neighbors(current) {
+			if _, seen := steps[next]; !seen {
+				steps[next] = steps[current] + 1
+				cameFrom[next] = current
+				frontier = append(frontier, next)
+			}
+		}
+	}
+
+	return Result[N]{}, false
+}
+
+// Dijkstra finds the cheapest route to a goal. Edge costs must not be
+// negative.
+func Dijkstra[N comparable](start N, neighbors func(N) []Edge[N], isGoal func(N) bool) (Result[N], bool) {
+	return DijkstraFrom([]N{start}, neighbors, isGoal)
+}
+
+// DijkstraFrom finds the cheapest route to a goal from any of several start
+// nodes. The result's Start is the one the route begins at.
+func DijkstraFrom[N comparable](starts []N, neighbors func(N) []Edge[N], isGoal func(N) bool) (Result[N], bool) {
+	return aStar(starts, neighbors, isGoal, func(N) int { return 0 })
+}
+
+// AStar is Dijkstra guided by a heuristic estimate of the remaining cost to
+// the nearest goal. The heuristic must never overestimate (and must be
+// consistent), otherwise the route found may not be the cheapest.
+func AStar[N comparable](start N, neighbors func(N) []Edge[N], isGoal func(N) bool, heuristic func(N) int) (Result[N], bool) {
+	return aStar([]N{start}, neighbors, isGoal, heuristic)
+}
+
+func aStar[N comparable](starts []N, neighbors func(N) []Edge[N], isGoal func(N) bool, heuristic func(N) int) (Result[N], bool) {
+	frontier := io.NewMinQueue[N]()
+	queued := map[N]*io.PQItem[N]{}
+	cameFrom := map[N]N{}
+	costSoFar := map[N]int{}
+	for _, start := range starts {
+		costSoFar[start] = 0
+		push(frontier, queued, start, heuristic(start))
+	}
+
+	for frontier.Len() > 0 {
+		current, _ := frontier.Pop()
+
+		if isGoal(current) {
+			start := current
+			for prev, ok := cameFrom[start]; ok; prev, ok = cameFrom[start] {
+				start = prev
+			}
+			return Result[N]{start, current, costSoFar[current], cameFrom}, true
+		}
+
+		for _, edge := range neighbors(current) {
+			newCost := costSoFar[current] + edge.Cost
+			if oldCost, ok := costSoFar[edge.To]; !ok || newCost < oldCost {
+				costSoFar[edge.To] = newCost
+				cameFrom[edge.To] = current
+				push(frontier, queued, edge.To, newCost+heuristic(edge.To))
+			}
+		}
+	}
+
+	return Result[N]{}, false
+}
+
+// push queues n with the given priority, or lowers its priority if it is
+// already waiting in the frontier.
+func push[N comparable](frontier *io.PriorityQueue[N], queued map[N]*io.PQItem[N], n N, priority int) {
+	if item, ok := queued[n]; ok && item.Index >= 0 {
+		frontier.Update(item, priority)
+		return
+	}
+	queued[n] = frontier.Push(n, priority)
+}
+
+// Optimal describes every cheapest route from a start to any goal node.
+type Optimal[N comparable] struct {
+	Starts []N
+	Cost   int
+	// Goals holds each goal node that can be reached at the optimal cost.
+	Goals []N
+
+	preds map[N][]N
+	// later holds predecessors found after the node was settled, which only
+	// zero-cost edges produce. They are left out of preds to keep it acyclic.
+	later map[N][]N
+}
+
+// Predecessors returns the nodes that precede n on at least one cheapest
+// route to n.
+func (o Optimal[N]) Predecessors(n N) []N {
+	return append(slices.Clone(o.preds[n]), o.later[n]...)
+}
+
+// Nodes returns every node that lies on at least one optimal route, found by
+// walking the predecessor graph back from the goals.
+func (o Optimal[N]) Nodes() []N {
+	seen := map[N]bool{}
+	nodes := []N{}
+	stack := append([]N{}, o.Goals...)
+	for len(stack) > 0 {
+		current := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if seen[current] {
+			continue
+		}
+		seen[current] = true
+		nodes = append(nodes, current)
+		stack = append(stack, o.Predecessors(current)...)
+	}
+	return nodes
+}
+
+// CountPaths returns how many distinct cheapest routes there are, from any
+// start to any goal. The count can grow exponentially with the size of the
+// graph, so it is returned as a big.Int. Routes that would take a zero-cost
+// edge back to a node settled earlier aren't counted, as with zero-cost
+// cycles there would be no end to them.
+func (o Optimal[N]) CountPaths() *big.Int {
+	isStart := map[N]bool{}
+	for _, s := range o.Starts {
+		isStart[s] = true
+	}
+	counts := map[N]*big.Int{}
+	var count func(n N) *big.Int
+	count = func(n N) *big.Int {
+		if c, ok := counts[n]; ok {
+			return c
+		}
+		c := new(big.Int)
+		if isStart[n] {
+			c.SetInt64(1)
+		}
+		counts[n] = c
+		for _, p := range o.preds[n] {
+			c.Add(c, count(p))
+		}
+		return c
+	}
+
+	total := new(big.Int)
+	for _, g := range o.Goals {
+		total.Add(total, count(g))
+	}
+	return total
+}
+
+// Paths returns up to limit of the cheapest routes, each from a start to a
+// goal inclusive.
+func (o Optimal[N]) Paths(limit int) [][]N {
+	isStart := map[N]bool{}
+	for _, s := range o.Starts {
+		isStart[s] = true
+	}
+	paths := [][]N{}
+	reversed := []N{}
+	var walk func(n N)
+	walk = func(n N) {
+		if len(paths) >= limit {
+			return
+		}
+		reversed = append(reversed, n)
+		if isStart[n] {
+			path := slices.Clone(reversed)
+			slices.Reverse(path)
+			paths = append(paths, path)
+		}
+		for _, p := range o.preds[n] {
+			walk(p)
+		}
+		reversed = reversed[:len(reversed)-1]
+	}
+	for _, g := range o.Goals {
+		walk(g)
+	}
+	return paths
+}
+
+// AllOptimal runs Dijkstra, but instead of a single route it records every
+// predecessor that reaches a node at its cheapest cost. The search continues
+// until all goals reachable at the optimal cost have been found.
+func AllOptimal[N comparable](start N, neighbors func(N) []Edge[N], isGoal func(N) bool) (Optimal[N], bool) {
+	return AllOptimalFrom([]N{start}, neighbors, isGoal)
+}
+
+// AllOptimalFrom is AllOptimal starting from several nodes at once. Routes
+// from every start are kept, as long as they are among the cheapest overall.
+//
+// The predecessor graph CountPaths and Paths walk follows the order nodes are
+// settled in, so it can never contain a cycle, even with zero-cost edges.
+// Where zero-cost edges join nodes of equal cost, only the routes through
+// them in that order are counted, but Nodes and Predecessors include the
+// others too.
+func AllOptimalFrom[N comparable](starts []N, neighbors func(N) []Edge[N], isGoal func(N) bool) (Optimal[N], bool) {
+	frontier := io.NewMinQueue[N]()
+	queued := map[N]*io.PQItem[N]{}
+	costSoFar := map[N]int{}
+	for _, start := range starts {
+		costSoFar[start] = 0
+		push(frontier, queued, start, 0)
+	}
+	preds, later := map[N][]N{}, map[N][]N{}
+	settled := map[N]bool{}
+	result := Optimal[N]{Starts: starts, Cost: -1, preds: preds, later: later}
+
+	for frontier.Len() > 0 {
+		current, cost := frontier.Pop()
+		if result.Cost >= 0 && cost > result.Cost {
+			break
+		}
+		settled[current] = true
+
+		if isGoal(current) {
+			result.Cost = cost
+			result.Goals = append(result.Goals, current)
+			continue
+		}
+
+		for _, edge := range neighbors(current) {
+			newCost := cost + edge.Cost
+			if settled[edge.To] {
+				if newCost == costSoFar[edge.To] {
+					later[edge.To] = append(later[edge.To], current)
+				}
+				continue
+			}
+			oldCost, ok := costSoFar[edge.To]
+			if !ok || newCost < oldCost {
+				costSoFar[edge.To] = newCost
+				preds[edge.To] = []N{current}
+				push(frontier, queued, edge.To, newCost)
+			} else if newCost == oldCost {
+				preds[edge.To] = append(preds[edge.To], current)
+			}
+		}
+	}
+
+	return result, result.Cost >= 0
+}
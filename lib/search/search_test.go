@@ -0,0 +1,202 @@
+package search
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/faideww/aoc-2024/lib/geom"
+	"github.com/faideww/aoc-2024/lib/grid"
+)
+
+// maze has a single shortest route of 8 steps from the top left to the
+// bottom right corner.
+const maze = `...#.
+##.#.
+...#.
+.#...
+...#.`
+
+// weights is a grid where entering a cell costs its digit.
+const weights = `1163
+1381
+2136
+3191`
+
+func mustParse(t *testing.T, input string) *grid.Grid[rune] {
+	t.Helper()
+	g, err := grid.Parse(input)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return g
+}
+
+// steps returns the open cells next to p.
+func steps(g *grid.Grid[rune]) func(geom.Vec2) []geom.Vec2 {
+	return func(p geom.Vec2) []geom.Vec2 {
+		next := []geom.Vec2{}
+		for n, r := range g.Neighbors4(p) {
+			if r != '#' {
+				next = append(next, n)
+			}
+		}
+		return next
+	}
+}
+
+// edges returns the open cells next to p, costing 1 each or their digit.
+func edges(g *grid.Grid[rune]) func(geom.Vec2) []Edge[geom.Vec2] {
+	return func(p geom.Vec2) []Edge[geom.Vec2] {
+		next := []Edge[geom.Vec2]{}
+		for n, r := range g.Neighbors4(p) {
+			switch {
+			case r == '#':
+			case r >= '0' && r <= '9':
+				next = append(next, Edge[geom.Vec2]{To: n, Cost: int(r - '0')})
+			default:
+				next = append(next, Edge[geom.Vec2]{To: n, Cost: 1})
+			}
+		}
+		return next
+	}
+}
+
+func at(goal geom.Vec2) func(geom.Vec2) bool {
+	return func(p geom.Vec2) bool { return p == goal }
+}
+
+// checkRoute checks that r's path runs from its start to its goal through
+// adjacent open cells, and costs what r says it does.
+func checkRoute(t *testing.T, name string, g *grid.Grid[rune], r Result[geom.Vec2]) {
+	t.Helper()
+	path := r.Path()
+	if path[0] != r.Start || path[len(path)-1] != r.Goal {
+		t.Errorf("%s: path %v doesn't run from %v to %v", name, path, r.Start, r.Goal)
+		return
+	}
+	cost := 0
+	for i := 1; i < len(path); i++ {
+		if path[i-1].Manhattan(path[i]) != 1 || g.Get(path[i]) == '#' {
+			t.Errorf("%s: path %v takes an invalid step at %v", name, path, path[i])
+			return
+		}
+		cost++
+		if r := g.Get(path[i]); r >= '0' && r <= '9' {
+			cost += int(r-'0') - 1
+		}
+	}
+	if cost != r.Cost {
+		t.Errorf("%s: path %v costs %d, but the result says %d", name, path, cost, r.Cost)
+	}
+}
+
+func TestShortestRoutes(t *testing.T) {
+	corner := geom.Vec2{X: 4, Y: 4}
+	mazeGrid, weightGrid := mustParse(t, maze), mustParse(t, weights)
+	manhattan := func(goal geom.Vec2) func(geom.Vec2) int {
+		return func(p geom.Vec2) int { return p.Manhattan(goal) }
+	}
+
+	tests := []struct {
+		name  string
+		g     *grid.Grid[rune]
+		find  func() (Result[geom.Vec2], bool)
+		start geom.Vec2
+		cost  int
+	}{
+		{"BFS", mazeGrid, func() (Result[geom.Vec2], bool) {
+			return BFS(geom.Vec2{}, steps(mazeGrid), at(corner))
+		}, geom.Vec2{}, 8},
+		{"BFS to the start", mazeGrid, func() (Result[geom.Vec2], bool) {
+			return BFS(geom.Vec2{}, steps(mazeGrid), at(geom.Vec2{}))
+		}, geom.Vec2{}, 0},
+		{"Dijkstra on the maze", mazeGrid, func() (Result[geom.Vec2], bool) {
+			return Dijkstra(geom.Vec2{}, edges(mazeGrid), at(corner))
+		}, geom.Vec2{}, 8},
+		{"Dijkstra weighted", weightGrid, func() (Result[geom.Vec2], bool) {
+			return Dijkstra(geom.Vec2{}, edges(weightGrid), at(geom.Vec2{X: 3, Y: 3}))
+		}, geom.Vec2{}, 14},
+		{"DijkstraFrom the nearer start", mazeGrid, func() (Result[geom.Vec2], bool) {
+			return DijkstraFrom([]geom.Vec2{{X: 0, Y: 0}, {X: 4, Y: 0}}, edges(mazeGrid), at(corner))
+		}, geom.Vec2{X: 4, Y: 0}, 4},
+		{"DijkstraFrom the other nearer start", mazeGrid, func() (Result[geom.Vec2], bool) {
+			return DijkstraFrom([]geom.Vec2{{X: 0, Y: 0}, {X: 4, Y: 0}}, edges(mazeGrid), at(geom.Vec2{X: 0, Y: 2}))
+		}, geom.Vec2{X: 0, Y: 0}, 6},
+		{"AStar on the maze", mazeGrid, func() (Result[geom.Vec2], bool) {
+			return AStar(geom.Vec2{}, edges(mazeGrid), at(corner), manhattan(corner))
+		}, geom.Vec2{}, 8},
+		{"AStar weighted", weightGrid, func() (Result[geom.Vec2], bool) {
+			goal := geom.Vec2{X: 3, Y: 3}
+			return AStar(geom.Vec2{}, edges(weightGrid), at(goal), manhattan(goal))
+		}, geom.Vec2{}, 14},
+		{"AStar weighted, other goal", weightGrid, func() (Result[geom.Vec2], bool) {
+			goal := geom.Vec2{X: 3, Y: 0}
+			return AStar(geom.Vec2{}, edges(weightGrid), at(goal), manhattan(goal))
+		}, geom.Vec2{}, 10},
+	}
+	for _, tt := range tests {
+		r, ok := tt.find()
+		if !ok {
+			t.Errorf("%s: found no route", tt.name)
+			continue
+		}
+		if r.Start != tt.start || r.Cost != tt.cost {
+			t.Errorf("%s: route from %v costs %d, want from %v costing %d", tt.name, r.Start, r.Cost, tt.start, tt.cost)
+		}
+		checkRoute(t, tt.name, tt.g, r)
+	}
+
+	// the maze's only shortest route
+	r, _ := BFS(geom.Vec2{}, steps(mazeGrid), at(corner))
+	want := []geom.Vec2{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2}, {X: 2, Y: 3}, {X: 3, Y: 3}, {X: 4, Y: 3}, {X: 4, Y: 4}}
+	if got := r.Path(); !slices.Equal(got, want) {
+		t.Errorf("BFS path = %v, want %v", got, want)
+	}
+}
+
+func TestUnreachableGoal(t *testing.T) {
+	g := mustParse(t, ".#.\n##.\n...")
+	goal := at(geom.Vec2{X: 2, Y: 2})
+	if _, ok := BFS(geom.Vec2{}, steps(g), goal); ok {
+		t.Errorf("BFS found a route to a walled-off goal")
+	}
+	if _, ok := Dijkstra(geom.Vec2{}, edges(g), goal); ok {
+		t.Errorf("Dijkstra found a route to a walled-off goal")
+	}
+	if _, ok := DijkstraFrom([]geom.Vec2{{X: 0, Y: 0}}, edges(g), goal); ok {
+		t.Errorf("DijkstraFrom found a route to a walled-off goal")
+	}
+	if _, ok := AStar(geom.Vec2{}, edges(g), goal, func(geom.Vec2) int { return 0 }); ok {
+		t.Errorf("AStar found a route to a walled-off goal")
+	}
+	if _, ok := AllOptimal(geom.Vec2{}, edges(g), goal); ok {
+		t.Errorf("AllOptimal found a route to a walled-off goal")
+	}
+}
+
+func TestAllOptimalZeroCostCycle(t *testing.T) {
+	// a and b are joined both ways by free edges, and both lead to the goal
+	edges := map[string][]Edge[string]{
+		"a": {{To: "b", Cost: 0}, {To: "goal", Cost: 1}},
+		"b": {{To: "a", Cost: 0}, {To: "goal", Cost: 1}},
+	}
+	neighbors := func(n string) []Edge[string] { return edges[n] }
+	isGoal := func(n string) bool { return n == "goal" }
+
+	routes, ok := AllOptimal("a", neighbors, isGoal)
+	if !ok || routes.Cost != 1 {
+		t.Fatalf("got cost %d (ok %v), want 1", routes.Cost, ok)
+	}
+	if got := routes.CountPaths().Int64(); got != 2 {
+		t.Errorf("CountPaths = %d, want 2", got)
+	}
+	if got := routes.Paths(10); len(got) != 2 {
+		t.Errorf("Paths returned %d routes, want 2: %v", len(got), got)
+	}
+	if got := len(routes.Nodes()); got != 3 {
+		t.Errorf("Nodes returned %d nodes, want 3", got)
+	}
+	if got := routes.Predecessors("a"); len(got) != 1 || got[0] != "b" {
+		t.Errorf("Predecessors(a) = %v, want [b]", got)
+	}
+}